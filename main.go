@@ -76,11 +76,49 @@ func main() {
 			}
 
 			pricingModel := pricingservice.NewF1QuantumPricingModel(totalNumberOfRaces, lastRound, totalPointsSeason)
+			if pricingModel.Teams, err = readTeamRegistry(); err != nil {
+				fmt.Println("Error reading team registry:", err)
+				return
+			}
+			ageSeasons, err := pricingservice.AgeCurveSeasonsV1(drivers, pricingModel.Teams)
+			if err != nil {
+				fmt.Println("Error reading driver seasons:", err)
+				return
+			}
+			pricingModel.AgeCurve = readAgeCurve(ageSeasons)
 
-			driverPrices := pricingModel.ProcessAllDrivers(drivers)
+			rules, err := readPriceRules()
+			if err != nil {
+				fmt.Println("Error reading price rules:", err)
+				return
+			}
+			pricingModel.Rules = rules
+
+			if err := readPriceRounding(&pricingModel.Rounding); err != nil {
+				fmt.Println("Error selecting price rounding:", err)
+				return
+			}
+
+			driverPrices, err := pricingModel.ProcessAllDrivers(drivers)
+			if err != nil {
+				fmt.Println("Error pricing drivers:", err)
+				return
+			}
 			pricingModel.PrintDriverAttributesTable(driverPrices)
 			pricingModel.PrintDriverAbilitiesTable(driverPrices)
 			pricingModel.PrintDriverPrices(driverPrices)
+			if rules != nil {
+				var ruleLog []pricingservice.PriceRuleLogEntry
+				for _, dp := range driverPrices {
+					ruleLog = append(ruleLog, dp.RuleLog...)
+				}
+				pricingservice.PrintRuleLog(ruleLog)
+			}
+
+			runSensitivity(func(cfg pricingservice.SensitivityConfig) pricingservice.SensitivityReport {
+				report, _ := pricingModel.Sensitivity(drivers, cfg) // teams already checked by ProcessAllDrivers
+				return report
+			})
 			return
 		}
 
@@ -91,12 +129,147 @@ func main() {
 				return
 			}
 
-			pricingModel := &pricingservice.F1QuantumPricingModelV2{}
+			pricingModel := pricingservice.NewF1QuantumPricingModelV2()
+			if pricingModel.Teams, err = readTeamRegistry(); err != nil {
+				fmt.Println("Error reading team registry:", err)
+				return
+			}
+			ageSeasons, err := pricingservice.AgeCurveSeasonsV2(drivers, pricingModel.Teams)
+			if err != nil {
+				fmt.Println("Error reading driver seasons:", err)
+				return
+			}
+			pricingModel.AgeCurve = readAgeCurve(ageSeasons)
+
+			if referencePath := GetInput("Reference data Json file path (blank for built-in tiers): "); referencePath != "" {
+				reference, err := pricingservice.LoadReferenceDataV2(referencePath)
+				if err != nil {
+					fmt.Println("Error reading reference data:", err)
+					return
+				}
+				pricingModel.Reference = reference
+			}
+
+			if profilePath := GetInput("Weight profile Json file path (blank for default weights): "); profilePath != "" {
+				profile, err := pricingservice.LoadWeightProfileV2(profilePath)
+				if err != nil {
+					fmt.Println("Error reading weight profile:", err)
+					return
+				}
+				pricingModel.UseWeightProfile(profile)
+			}
+			if err := runCalibration(pricingModel); err != nil {
+				fmt.Println("Error calibrating weights:", err)
+				return
+			}
+
+			rules, err := readPriceRules()
+			if err != nil {
+				fmt.Println("Error reading price rules:", err)
+				return
+			}
+			pricingModel.Rules = rules
+
+			overridesPath := GetInput("Editorial Overrides Json file path (blank for none): ")
+			if overridesPath != "" {
+				overrides, err := pricingservice.LoadEditorialOverridesV2(overridesPath)
+				if err != nil {
+					fmt.Println("Error reading editorial overrides:", err)
+					return
+				}
+				pricingModel.Overrides = overrides
+			}
+
+			followersPath := GetInput("Followers CSV file path (blank for none): ")
+			if followersPath != "" {
+				followers, err := pricingservice.LoadFollowersCSV(followersPath)
+				if err != nil {
+					fmt.Println("Error reading followers data:", err)
+					return
+				}
+				pricingModel.Followers = followers
+			}
+
+			ownershipPath := GetInput("Ownership CSV file path (blank for none): ")
+			if ownershipPath != "" {
+				ownership, err := pricingservice.LoadOwnershipCSV(ownershipPath)
+				if err != nil {
+					fmt.Println("Error reading ownership data:", err)
+					return
+				}
+				pricingModel.Ownership = ownership
+			}
+
+			curve, err := pricingservice.NewF1PriceCurveV2(GetInput("Price curve (linear/power/tiers/quantile, blank for linear): "))
+			if err != nil {
+				fmt.Println("Error selecting price curve:", err)
+				return
+			}
+			pricingModel.PriceCurve = curve
+
+			if err := readPriceRounding(&pricingModel.Rounding); err != nil {
+				fmt.Println("Error selecting price rounding:", err)
+				return
+			}
 
 			driversSet := pricingModel.NewDriverSet(drivers)
 			teams := pricingModel.BuildTeamMapFromDrivers(driversSet)
-			pricingModel.PopulateDriverStats(driversSet, teams)
-			pricingModel.PriceDrivers(driversSet, 50, 2)
+
+			amendmentsPath := GetInput("Steward Amendments Json file path (blank for none): ")
+			if amendmentsPath != "" {
+				amendments, err := pricingservice.LoadResultAmendmentsV2(amendmentsPath)
+				if err != nil {
+					fmt.Println("Error reading steward amendments:", err)
+					return
+				}
+				amendmentLog, err := pricingModel.ApplyAmendments(driversSet, teams, amendments)
+				if err != nil {
+					fmt.Println("Error applying steward amendments:", err)
+					return
+				}
+				pricingservice.PrintAmendmentLog(amendmentLog)
+			}
+
+			if err := pricingModel.PopulateDriverStats(driversSet, teams); err != nil {
+				fmt.Println("Error computing driver stats:", err)
+				return
+			}
+			pricingservice.PrintTierWarnings(pricingModel.TierWarnings)
+			pricingModel.PrintDriverPriorsTable(driversSet)
+			pricingModel.PrintShrinkageReport(driversSet)
+			pricingModel.PrintReliabilityTable(driversSet)
+			pricingservice.PrintTeammateH2HTable(driversSet)
+			prices := pricingModel.PriceDrivers(driversSet, 50, 2)
+			pricingModel.PrintDriverPrices(prices)
+			pricingModel.PrintBandSolution()
+			pricingservice.PrintDemandTable(driversSet)
+			pricingservice.PrintProjectionTable(prices)
+			if sheetPath := GetInput("Price sheet CSV output path (blank to skip): "); sheetPath != "" {
+				if err := pricingservice.WritePriceSheetCSV(sheetPath, prices); err != nil {
+					fmt.Println("Error writing price sheet CSV:", err)
+				} else {
+					fmt.Println("Price sheet written to", sheetPath)
+				}
+			}
+			if ruleLog := pricingservice.RuleLogV2(prices); len(ruleLog) > 0 {
+				pricingservice.PrintRuleLog(ruleLog)
+				if err := pricingservice.AppendAuditLog("price_audit.jsonl", ruleLog); err != nil {
+					fmt.Println("Error writing audit log:", err)
+				}
+			}
+
+			runSensitivity(func(cfg pricingservice.SensitivityConfig) pricingservice.SensitivityReport {
+				return pricingModel.Sensitivity(driversSet, 50, 2, cfg)
+			})
+
+			if strings.EqualFold(GetInput("Run live race weekend? (y/N): "), "y") {
+				// start from the amended data so the steward decisions above carry over
+				amended := make([]pricingservice.F1BasicDriverDataV2, len(driversSet))
+				for i, d := range driversSet {
+					amended[i] = d.BasicData
+				}
+				runLiveWeekend(pricingModel, amended)
+			}
 			return
 		}
 
@@ -140,6 +313,149 @@ func readFormula1DriversV2FromJSON(filePath string) ([]pricingservice.F1BasicDri
 	return drivers, nil
 }
 
+// runLiveWeekend feeds session event files into an in-memory pricing state
+// and prints the updated sheet after each one.
+func runLiveWeekend(model *pricingservice.F1QuantumPricingModelV2, drivers []pricingservice.F1BasicDriverDataV2) {
+	weekend, err := pricingservice.NewF1LiveWeekendV2(model, drivers, 50, 2)
+	if err != nil {
+		fmt.Println("Error starting live weekend:", err)
+		return
+	}
+	for {
+		input := GetInput("Event Json file path, 'rollback <id>', or blank to finish: ")
+		if input == "" {
+			return
+		}
+		var (
+			update pricingservice.F1WeekendUpdateV2
+			err    error
+		)
+		if idStr, ok := strings.CutPrefix(input, "rollback "); ok {
+			id, convErr := strconv.Atoi(strings.TrimSpace(idStr))
+			if convErr != nil {
+				fmt.Println("Error reading event id:", convErr)
+				continue
+			}
+			update, err = weekend.Rollback(id)
+		} else {
+			event, loadErr := pricingservice.LoadWeekendEventV2(input)
+			if loadErr != nil {
+				fmt.Println("Error reading event:", loadErr)
+				continue
+			}
+			update, err = weekend.Apply(event)
+		}
+		if err != nil {
+			fmt.Println("Error updating weekend:", err)
+			continue
+		}
+		weekend.PrintWeekendUpdate(update)
+	}
+}
+
+// readPriceRules asks for an optional price rules file; blank means no rules.
+func readPriceRules() (*pricingservice.PriceRuleSet, error) {
+	path := GetInput("Price Rules Json file path (blank for none): ")
+	if path == "" {
+		return nil, nil
+	}
+	return pricingservice.LoadPriceRuleSet(path)
+}
+
+// readPriceRounding asks for a rounding strategy; blank keeps the model default.
+func readPriceRounding(rounding *pricingservice.PriceRounding) error {
+	name := GetInput("Price rounding (half-up/tick-0.1/nine-endings/team-anchor/tier-anchor/none, blank for default): ")
+	if name != "" {
+		strategy, err := pricingservice.NewPriceRounder(name)
+		if err != nil {
+			return err
+		}
+		rounding.Strategy = strategy
+	}
+	rounding.DistinctTeammates = strings.EqualFold(GetInput("Keep teammates on distinct prices? (y/N): "), "y")
+	return nil
+}
+
+// readTeamRegistry loads a team registry file, or returns the built-in one
+// when no path is given.
+func readTeamRegistry() (*pricingservice.TeamRegistry, error) {
+	path := GetInput("Team registry Json file path (blank for built-in teams): ")
+	if path == "" {
+		return pricingservice.NewTeamRegistry(), nil
+	}
+	return pricingservice.LoadTeamRegistry(path)
+}
+
+// readAgeCurve optionally fits the career age curve to the drivers' own
+// seasons; the default curve is kept when declined or when the fit fails.
+func readAgeCurve(seasons []pricingservice.AgeCurveSeason) pricingservice.AgeCurve {
+	if !strings.EqualFold(GetInput("Fit the career age curve from the drivers' seasons? (y/N): "), "y") {
+		return pricingservice.NewAgeCurve()
+	}
+	curve, err := pricingservice.FitAgeCurve(seasons, pricingservice.NewAgeCurveConfig())
+	if err != nil {
+		fmt.Println("Error fitting age curve, using the default:", err)
+		return pricingservice.NewAgeCurve()
+	}
+	pricingservice.PrintAgeCurve(curve)
+	return curve
+}
+
+// runCalibration optionally fits the rawScore weights to historical fantasy
+// points, saves the profile and switches the model to it.
+func runCalibration(model *pricingservice.F1QuantumPricingModelV2) error {
+	roundsPath := GetInput("Calibration rounds Json file path (blank to skip): ")
+	if roundsPath == "" {
+		return nil
+	}
+	rounds, err := pricingservice.LoadCalibrationRoundsV2(roundsPath)
+	if err != nil {
+		return err
+	}
+	report, err := model.CalibrateWeights(rounds, pricingservice.NewF1CalibrationConfigV2())
+	if err != nil {
+		return err
+	}
+	pricingservice.PrintCalibrationReport(report)
+	if outPath := GetInput("Weight profile output path (blank to skip): "); outPath != "" {
+		if err := pricingservice.WriteWeightProfileV2(outPath, report.Profile); err != nil {
+			return err
+		}
+		fmt.Println("Weight profile written to", outPath)
+	}
+	if strings.EqualFold(GetInput("Price with the fitted weights? (y/N): "), "y") {
+		model.UseWeightProfile(report.Profile)
+	}
+	return nil
+}
+
+// runSensitivity optionally perturbs the model parameters and prints / exports
+// the effect on the price sheet.
+func runSensitivity(run func(pricingservice.SensitivityConfig) pricingservice.SensitivityReport) {
+	if !strings.EqualFold(GetInput("Run weight sensitivity analysis? (y/N): "), "y") {
+		return
+	}
+	cfg := pricingservice.NewSensitivityConfig()
+	if stepStr := GetInput("Perturbation step in % (blank for 10): "); stepStr != "" {
+		step, err := strconv.ParseFloat(stepStr, 64)
+		if err != nil || step <= 0 || step >= 100 {
+			fmt.Println("Error reading perturbation step:", stepStr)
+			return
+		}
+		cfg.Step = step / 100
+	}
+
+	report := run(cfg)
+	pricingservice.PrintSensitivityTable(report)
+	if csvPath := GetInput("Sensitivity CSV output path (blank to skip): "); csvPath != "" {
+		if err := pricingservice.WriteSensitivityCSV(csvPath, report); err != nil {
+			fmt.Println("Error writing sensitivity CSV:", err)
+			return
+		}
+		fmt.Println("Sensitivity CSV written to", csvPath)
+	}
+}
+
 func GetUserChoice() int {
 	reader := bufio.NewReader(os.Stdin)
 	input, _ := reader.ReadString('\n')
@@ -0,0 +1,307 @@
+package pricingservice
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+//
+// CAREER AGE CURVE (shared by every model)
+//
+
+// A driver's expected change in form over the next season, from their age
+// and experience:
+//
+//	Δshare = Intercept + AgeSlope·age + Learning·exp(−starts ÷ LearningStarts)
+//
+// Form is the share of the team's points the driver scored, which takes
+// the car out of the comparison. The age part rises before the peak
+// (−Intercept ÷ AgeSlope) and falls after it; the learning part is the
+// extra step a driver with few starts still has to make.
+//
+// The curve is fitted with the delta method: every pair of consecutive
+// seasons of the same driver with the same team gives one observed Δshare
+// at the age and starts of the first season (a new car or teammate would
+// swamp the age effect). The fit is a weighted least squares (weight
+// = races in the shorter season), pulled towards the default curve with
+// PriorWeight, so a handful of pairs moves it only a little.
+
+const (
+	defaultAgeCurveIntercept = 0.28  // Δshare per season at age 0 …
+	defaultAgeCurveSlope     = -0.01 // … falling 1 pt of share per year: peak at 28
+	defaultAgeCurveLearning  = 0.03  // extra Δshare of a driver with no starts
+	defaultLearningStarts    = 50.0
+	defaultAgeCurveMinRaces  = 5
+	defaultAgeCurvePrior     = 40.0 // pseudo-races of the default curve
+	defaultAgeTrendScale     = 0.10 // Δshare that maps to a full ±1 trend
+)
+
+// AgeCurve is the expected next-season change in team points share.
+type AgeCurve struct {
+	Intercept      float64
+	AgeSlope       float64
+	Learning       float64
+	LearningStarts float64
+
+	// fit summary (zero for the default curve)
+	Pairs int
+	RMSE  float64
+}
+
+func NewAgeCurve() AgeCurve {
+	return AgeCurve{
+		Intercept:      defaultAgeCurveIntercept,
+		AgeSlope:       defaultAgeCurveSlope,
+		Learning:       defaultAgeCurveLearning,
+		LearningStarts: defaultLearningStarts,
+	}
+}
+
+// Delta is the expected change in team points share over the next season
+// of a driver of this age with this many career starts (0 if age unknown).
+func (c AgeCurve) Delta(age, starts int) float64 {
+	if age <= 0 {
+		return 0
+	}
+	return c.Intercept + c.AgeSlope*float64(age) + c.Learning*c.learning(starts)
+}
+
+func (c AgeCurve) learning(starts int) float64 {
+	if c.LearningStarts <= 0 {
+		return 0
+	}
+	return math.Exp(-float64(max(starts, 0)) / c.LearningStarts)
+}
+
+// Trend maps Delta onto −1…+1, full scale at ±defaultAgeTrendScale.
+func (c AgeCurve) Trend(age, starts int) float64 {
+	return clamp(c.Delta(age, starts)/defaultAgeTrendScale, -1, 1)
+}
+
+// Peak is the age at which the age part of the curve turns from
+// improvement to decline (ok is false when the fit found no decline).
+func (c AgeCurve) Peak() (age float64, ok bool) {
+	if c.AgeSlope >= 0 {
+		return 0, false
+	}
+	return -c.Intercept / c.AgeSlope, true
+}
+
+// AgeCurveSeason is one season of one driver as the fit reads it.
+type AgeCurveSeason struct {
+	Driver string
+	Team   string // registry ID, so a rebrand does not split a pair
+	Year   int
+	Age    int // age during the season
+	Starts int // career starts at the end of the season
+	Races  int
+	Share  float64 // driver points ÷ team points
+}
+
+// AgeCurveConfig sets which seasons enter the fit and how strongly it is
+// held to the default curve.
+type AgeCurveConfig struct {
+	MinRaces    int     // seasons shorter than this are left out
+	PriorWeight float64 // pseudo-races of the default curve (0 = plain WLS)
+}
+
+func NewAgeCurveConfig() AgeCurveConfig {
+	return AgeCurveConfig{MinRaces: defaultAgeCurveMinRaces, PriorWeight: defaultAgeCurvePrior}
+}
+
+// ageCurveSeason back-dates a driver's current age and career starts to a
+// past season: ref is the driver's newest season, racesSince the races
+// from the end of that past season on.
+func ageCurveSeason(name, team string, age, careerStarts, ref, year, racesSince, races int, share float64) AgeCurveSeason {
+	s := AgeCurveSeason{Driver: name, Team: team, Year: year, Races: races, Share: share}
+	if age > 0 {
+		s.Age = age - (ref - year)
+	}
+	s.Starts = max(careerStarts-racesSince, 0)
+	return s
+}
+
+// AgeCurveSeasonsV1 lists the seasons of v1 driver data for FitAgeCurve,
+// with team names resolved through teams; a name it cannot resolve is an
+// error.
+func AgeCurveSeasonsV1(drivers []F1BasicDriverData, teams *TeamRegistry) ([]AgeCurveSeason, error) {
+	var out []AgeCurveSeason
+	for _, d := range drivers {
+		seasons := append([]F1BasicSeasonStats(nil), d.Seasons...)
+		sort.SliceStable(seasons, func(i, j int) bool { return seasons[i].Year > seasons[j].Year })
+		if len(seasons) == 0 {
+			continue
+		}
+		since := 0
+		for _, s := range seasons {
+			if s.TeamPoints > 0 {
+				team, err := ageCurveTeam(teams, d.Name, s.Year, s.Team)
+				if err != nil {
+					return nil, err
+				}
+				out = append(out, ageCurveSeason(d.Name, team, d.Age, d.CareerStarts, seasons[0].Year, s.Year, since, s.Races, s.Points/s.TeamPoints))
+			}
+			since += s.Races
+		}
+	}
+	return out, nil
+}
+
+// AgeCurveSeasonsV2 lists the seasons of v2 driver data for FitAgeCurve,
+// with team names resolved through teams; stints of one year are merged,
+// with the share weighted by races.
+func AgeCurveSeasonsV2(drivers []F1BasicDriverDataV2, teams *TeamRegistry) ([]AgeCurveSeason, error) {
+	var out []AgeCurveSeason
+	for _, d := range drivers {
+		byYear := stintsByYear(d.Seasons)
+		years := yearsNewestFirst(byYear)
+		if len(years) == 0 {
+			continue
+		}
+		since := 0
+		for _, y := range years {
+			s := mergeStints(byYear[y])
+			share := stintTeamShare(byYear[y])
+			if s.TeamPoints > 0 {
+				team, err := ageCurveTeam(teams, d.Name, y, s.Team)
+				if err != nil {
+					return nil, err
+				}
+				out = append(out, ageCurveSeason(d.Name, team, d.Age, d.CareerStarts, years[0], y, since, s.Races, share))
+			}
+			since += s.Races
+		}
+	}
+	return out, nil
+}
+
+// ageCurveTeam resolves a season's team to its registry ID ("" when the
+// season names none).
+func ageCurveTeam(teams *TeamRegistry, driver string, year int, name string) (string, error) {
+	if name == "" {
+		return "", nil
+	}
+	id, err := teams.Resolve(name)
+	if err != nil {
+		return "", fmt.Errorf("driver %q, %d season: %v", driver, year, err)
+	}
+	return id, nil
+}
+
+// FitAgeCurve fits the curve to consecutive-season pairs, starting from
+// and shrunk towards the default curve. It errors when no usable pair
+// exists.
+func FitAgeCurve(seasons []AgeCurveSeason, cfg AgeCurveConfig) (AgeCurve, error) {
+	prior := NewAgeCurve()
+
+	type key struct {
+		driver string
+		year   int
+	}
+	byKey := make(map[key]AgeCurveSeason, len(seasons))
+	for _, s := range seasons {
+		if s.Races >= cfg.MinRaces && s.Age > 0 {
+			byKey[key{strings.ToLower(s.Driver), s.Year}] = s
+		}
+	}
+
+	keys := make([]key, 0, len(byKey))
+	for k := range byKey {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].driver != keys[j].driver {
+			return keys[i].driver < keys[j].driver
+		}
+		return keys[i].year < keys[j].year
+	})
+
+	var rows [][3]float64
+	var ys, ws []float64
+	for _, k := range keys {
+		s := byKey[k]
+		next, ok := byKey[key{k.driver, k.year + 1}]
+		if !ok || (s.Team != "" && next.Team != "" && s.Team != next.Team) {
+			continue
+		}
+		rows = append(rows, [3]float64{1, float64(s.Age), prior.learning(s.Starts)})
+		ys = append(ys, next.Share-s.Share)
+		ws = append(ws, float64(min(s.Races, next.Races)))
+	}
+	if len(rows) == 0 {
+		return prior, fmt.Errorf("no consecutive same-team seasons with %d+ races and a known age", cfg.MinRaces)
+	}
+
+	// (XᵀWX + λ·D) β = XᵀWy + λ·D·β₀, D scaling the prior to each column
+	var a mat.Dense
+	var rhs mat.VecDense
+	x := mat.NewDense(len(rows), 3, nil)
+	for i, r := range rows {
+		x.SetRow(i, r[:])
+	}
+	wx := mat.DenseCopyOf(x)
+	for i, w := range ws {
+		for j := 0; j < 3; j++ {
+			wx.Set(i, j, wx.At(i, j)*w)
+		}
+	}
+	a.Mul(x.T(), wx)
+	rhs.MulVec(wx.T(), mat.NewVecDense(len(ys), ys))
+
+	beta0 := []float64{prior.Intercept, prior.AgeSlope, prior.Learning}
+	scale := []float64{1, 28 * 28, 1} // age enters in years: keep the prior comparable
+	for j := 0; j < 3; j++ {
+		l := cfg.PriorWeight * scale[j]
+		a.Set(j, j, a.At(j, j)+l)
+		rhs.SetVec(j, rhs.AtVec(j)+l*beta0[j])
+	}
+	var beta mat.VecDense
+	if err := beta.SolveVec(&a, &rhs); err != nil {
+		return prior, fmt.Errorf("error fitting age curve: %v", err)
+	}
+
+	fit := AgeCurve{
+		Intercept:      beta.AtVec(0),
+		AgeSlope:       beta.AtVec(1),
+		Learning:       beta.AtVec(2),
+		LearningStarts: prior.LearningStarts,
+		Pairs:          len(rows),
+	}
+	var sse, wSum float64
+	for i, r := range rows {
+		e := ys[i] - (fit.Intercept + fit.AgeSlope*r[1] + fit.Learning*r[2])
+		sse += ws[i] * e * e
+		wSum += ws[i]
+	}
+	if wSum > 0 {
+		fit.RMSE = math.Sqrt(sse / wSum)
+	}
+	return fit, nil
+}
+
+// PrintAgeCurve prints the curve and the expected change at a few ages.
+func PrintAgeCurve(c AgeCurve) {
+	fmt.Println("\n=== CAREER AGE CURVE ===")
+	if c.Pairs > 0 {
+		fmt.Printf("Fitted on %d season pairs (weighted RMSE %.4f share)\n", c.Pairs, c.RMSE)
+	} else {
+		fmt.Println("Default curve (not fitted)")
+	}
+	fmt.Printf("Δshare = %+.4f %+.5f·age %+.4f·exp(−starts/%.0f)\n", c.Intercept, c.AgeSlope, c.Learning, c.LearningStarts)
+	if peak, ok := c.Peak(); ok {
+		fmt.Printf("Peak age: %.1f\n", peak)
+	} else {
+		fmt.Println("Peak age: none (no decline in the data)")
+	}
+	fmt.Println(strings.Repeat("-", 44))
+	fmt.Printf("%-6s %-12s %-12s %-12s\n", "AGE", "ROOKIE", "100 STARTS", "300 STARTS")
+	fmt.Println(strings.Repeat("-", 44))
+	for _, age := range []int{20, 24, 28, 32, 36, 40} {
+		fmt.Printf("%-6d %-+12.4f %-+12.4f %-+12.4f\n", age, c.Delta(age, 0), c.Delta(age, 100), c.Delta(age, 300))
+	}
+	fmt.Println(strings.Repeat("-", 44))
+}
@@ -0,0 +1,120 @@
+package pricingservice
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestAgeCurveDefaults(t *testing.T) {
+	c := NewAgeCurve()
+	if peak, ok := c.Peak(); !ok || !approx(peak, 28) {
+		t.Errorf("peak = %v (ok %v), want 28", peak, ok)
+	}
+	if d := c.Delta(28, 1000); math.Abs(d) > 1e-6 {
+		t.Errorf("Δ at the peak with a long career = %v, want ≈0", d)
+	}
+	if c.Delta(22, 100) <= c.Delta(34, 100) {
+		t.Errorf("young driver not expected to improve more than an old one")
+	}
+	if c.Delta(25, 0) <= c.Delta(25, 200) {
+		t.Errorf("learning term missing: Δ(25, 0) = %v, Δ(25, 200) = %v", c.Delta(25, 0), c.Delta(25, 200))
+	}
+}
+
+func TestAgeCurveSeasonsBackDate(t *testing.T) {
+	got, err := AgeCurveSeasonsV1([]F1BasicDriverData{{
+		Name: "A", Age: 30, CareerStarts: 100,
+		Seasons: []F1BasicSeasonStats{
+			{Year: 2023, Team: "Alfa Romeo", Races: 22, Points: 50, TeamPoints: 100},
+			{Year: 2025, Team: "Kick Sauber", Races: 10, Points: 20, TeamPoints: 50},
+			{Year: 2024, Races: 24, Points: 30, TeamPoints: 0}, // no team points: skipped
+		},
+	}}, NewTeamRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("%d seasons, want 2", len(got))
+	}
+	if got[0].Year != 2025 || got[0].Age != 30 || got[0].Starts != 100 || !approx(got[0].Share, 0.4) {
+		t.Errorf("2025 = %+v", got[0])
+	}
+	// 2023 ended 34 races (2024 + 2025) before the data was taken
+	if got[1].Year != 2023 || got[1].Age != 28 || got[1].Starts != 66 || !approx(got[1].Share, 0.5) {
+		t.Errorf("2023 = %+v", got[1])
+	}
+	if got[0].Team != "sauber" || got[1].Team != "sauber" {
+		t.Errorf("teams across a rebrand = %q / %q, want the registry ID", got[0].Team, got[1].Team)
+	}
+
+	v2, err := AgeCurveSeasonsV2([]F1BasicDriverDataV2{{
+		Name: "B", Age: 25, CareerStarts: 40,
+		Seasons: []F1BasicSeasonStatsV2{
+			{Year: 2025, Team: "Haas", Races: 6, Points: 30, TeamPoints: 60, FromRound: 1, ToRound: 6},
+			{Year: 2025, Team: "Alpine", Races: 12, Points: 10, TeamPoints: 100, FromRound: 7, ToRound: 18},
+		},
+	}}, NewTeamRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(v2) != 1 || v2[0].Races != 18 || !approx(v2[0].Share, (6*0.5+12*0.1)/18) {
+		t.Errorf("v2 merged stints = %+v", v2)
+	}
+
+	if _, err := AgeCurveSeasonsV2([]F1BasicDriverDataV2{{
+		Name: "C", Seasons: []F1BasicSeasonStatsV2{{Year: 2025, Team: "Minardi", Races: 6, TeamPoints: 10}},
+	}}, NewTeamRegistry()); err == nil || !strings.Contains(err.Error(), "Minardi") {
+		t.Errorf("unknown team error = %v", err)
+	}
+}
+
+func TestFitAgeCurve(t *testing.T) {
+	// synthetic drivers whose share moves by 0.5 − 0.02·age (peak 25)
+	var seasons []AgeCurveSeason
+	for i, start := range []int{20, 22, 24, 27, 30, 33, 36} {
+		share := 0.3
+		for k := 0; k < 4; k++ {
+			age := start + k
+			seasons = append(seasons, AgeCurveSeason{
+				Driver: string(rune('A' + i)), Year: 2020 + k, Age: age, Starts: 40*i + 22*k, Races: 22, Share: share,
+			})
+			share += 0.5 - 0.02*float64(age)
+		}
+	}
+
+	cfg := NewAgeCurveConfig()
+	cfg.PriorWeight = 0
+	fit, err := FitAgeCurve(seasons, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fit.Pairs != 21 {
+		t.Errorf("pairs = %d, want 21", fit.Pairs)
+	}
+	if peak, ok := fit.Peak(); !ok || math.Abs(peak-25) > 0.01 {
+		t.Errorf("fitted peak = %v (ok %v), want 25", peak, ok)
+	}
+	if fit.RMSE > 1e-6 {
+		t.Errorf("RMSE = %v on exact data", fit.RMSE)
+	}
+
+	// the default prior holds a fit on little data near the default curve
+	shrunk, _ := FitAgeCurve(seasons[:8], NewAgeCurveConfig())
+	if peak, _ := shrunk.Peak(); math.Abs(peak-28) > math.Abs(peak-25) {
+		t.Errorf("two drivers moved the peak to %v, want it nearer the default 28", peak)
+	}
+
+	if _, err := FitAgeCurve(seasons[:1], cfg); err == nil {
+		t.Errorf("fit on one season succeeded")
+	}
+
+	// a team change breaks the pair
+	moved := []AgeCurveSeason{
+		{Driver: "X", Team: "Red", Year: 2024, Age: 25, Races: 22, Share: 0.4},
+		{Driver: "X", Team: "Blue", Year: 2025, Age: 26, Races: 22, Share: 0.6},
+	}
+	if _, err := FitAgeCurve(moved, cfg); err == nil {
+		t.Errorf("fit across a team change succeeded")
+	}
+}
@@ -0,0 +1,59 @@
+package pricingservice
+
+import "math"
+
+//
+// PACE-ADJUSTED EXPECTED POSITION (shared by every model)
+//
+
+// Positions gained are measured against where a driver's pace put them,
+// not against the grid slot they started from: a pit-lane start after an
+// engine penalty says nothing about racecraft. The expected finish is the
+// qualifying position moved by the car's usual race drift.
+
+const maxRaceDrift = 4.0 // places a car's race pace may move it from its grid
+
+// raceDrift is how many places a car typically drops (positive) or gains
+// (negative) from qualifying to the flag: mean recent finish minus mean
+// recent qualifying position. 0 without both series.
+func raceDrift(racePositions, qualifyingPositions []float64) float64 {
+	if len(racePositions) == 0 || len(qualifyingPositions) == 0 {
+		return 0
+	}
+	var race, quali float64
+	for _, p := range racePositions {
+		race += p
+	}
+	for _, p := range qualifyingPositions {
+		quali += p
+	}
+	drift := race/float64(len(racePositions)) - quali/float64(len(qualifyingPositions))
+	return clamp(drift, -maxRaceDrift, maxRaceDrift)
+}
+
+// paceGrid is the grid slot a driver earned on pace: the qualifying
+// position when known, otherwise the start position with any grid penalty
+// taken back off. 0 means unknown.
+func paceGrid(qualifying, start, penaltyPlaces int) int {
+	switch {
+	case qualifying > 0:
+		return qualifying
+	case start > 0 && penaltyPlaces > 0:
+		return max(1, start-penaltyPlaces)
+	}
+	return start
+}
+
+// expectedFinish is the pace grid moved by the car's race drift, never
+// better than P1.
+func expectedFinish(grid int, drift float64) float64 {
+	return math.Max(1, float64(grid)+drift)
+}
+
+// paceGain is places made against the expected finish (0 without a grid).
+func paceGain(grid, finish int, drift float64) float64 {
+	if grid <= 0 || finish <= 0 {
+		return 0
+	}
+	return expectedFinish(grid, drift) - float64(finish)
+}
@@ -0,0 +1,127 @@
+package pricingservice
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ============================================================
+//  DNF CAUSES  (driver reliability vs car reliability)
+// ============================================================
+//
+// A retirement is charged to whoever caused it:
+//
+//	mechanical        the car  → team reliability (TeamReliabRaw)
+//	collision-fault   the driver
+//	driver-error      the driver
+//	collision-victim  nobody
+//
+// Driver-charged DNFs feed DNF3yRaw and ConsRaw (driver reliability);
+// mechanical DNFs feed the team reliability term. A DNF without a known
+// cause is charged to the driver, as every DNF was before causes were
+// recorded, and a team with no recorded mechanical causes falls back to its
+// total DNF count.
+
+// F1DNFCauseV2 is why a driver did not finish.
+type F1DNFCauseV2 string
+
+const (
+	F1MechanicalDNFV2      F1DNFCauseV2 = "mechanical"
+	F1CollisionFaultDNFV2  F1DNFCauseV2 = "collision-fault"
+	F1CollisionVictimDNFV2 F1DNFCauseV2 = "collision-victim"
+	F1DriverErrorDNFV2     F1DNFCauseV2 = "driver-error"
+)
+
+// F1DNFSplitV2 is a season's DNFs by who is charged for them.
+type F1DNFSplitV2 struct {
+	Driver  int // collision-fault + driver-error + unknown
+	Car     int // mechanical
+	Victim  int // collision-victim
+	Unknown int // no cause recorded (included in Driver)
+}
+
+// dnfCauses counts DNFs per cause: the season's DNFCauses totals when
+// given, otherwise the causes on its RecentRaces.
+func (s *F1BasicSeasonStatsV2) dnfCauses() map[F1DNFCauseV2]int {
+	if len(s.DNFCauses) > 0 {
+		return s.DNFCauses
+	}
+	out := make(map[F1DNFCauseV2]int)
+	for _, rr := range s.RecentRaces {
+		if rr.DNF && rr.DNFCause != "" {
+			out[rr.DNFCause]++
+		}
+	}
+	return out
+}
+
+// DNFSplit charges the season's DNFs to driver, car or nobody.
+func (s *F1BasicSeasonStatsV2) DNFSplit() F1DNFSplitV2 {
+	c := s.dnfCauses()
+	out := F1DNFSplitV2{
+		Car:    c[F1MechanicalDNFV2],
+		Victim: c[F1CollisionVictimDNFV2],
+	}
+	known := c[F1MechanicalDNFV2] + c[F1CollisionVictimDNFV2] + c[F1CollisionFaultDNFV2] + c[F1DriverErrorDNFV2]
+	out.Unknown = max(0, s.DNFs-known)
+	out.Driver = max(0, s.DNFs-out.Car-out.Victim)
+	return out
+}
+
+// DriverDNFRate = driver-charged DNFs ÷ races
+func (s *F1BasicSeasonStatsV2) DriverDNFRate() float64 {
+	if s.Races == 0 {
+		return 0
+	}
+	return float64(s.DNFSplit().Driver) / float64(s.Races)
+}
+
+// carDNFs sums the mechanical DNFs of every driver's current-season stints
+// with each team, keyed by registry ID; known is false for teams with no
+// recorded causes.
+func carDNFs(drvs []*F1CompleteDriverV2, teams *TeamRegistry) (mech map[string]int, known map[string]bool) {
+	mech, known = make(map[string]int), make(map[string]bool)
+	for _, d := range drvs {
+		team := d.TeamID
+		for _, s := range d.BasicData.seasonStints(d.BasicData.SeasonYear()) {
+			if s.Team != "" && !teams.is(s.Team, team) {
+				continue
+			}
+			c := s.dnfCauses()
+			if len(c) > 0 {
+				known[team] = true
+			}
+			mech[team] += c[F1MechanicalDNFV2]
+		}
+	}
+	return mech, known
+}
+
+// CarReliability = 1 − mechanical DNFs ÷ races (current season); falls
+// back to Reliability when no causes are known for the team.
+func (t *F1TeamDataV2) CarReliability(mechanical int, known bool) float64 {
+	if !known || t.CurrentRace == 0 {
+		return t.Reliability()
+	}
+	return 1 - float64(mechanical)/float64(t.CurrentRace)
+}
+
+// PrintReliabilityTable shows how each driver's live-season DNFs were charged.
+func (model *F1QuantumPricingModelV2) PrintReliabilityTable(drvs []*F1CompleteDriverV2) {
+	fmt.Println("\n=== DNF CAUSES: DRIVER vs CAR RELIABILITY ===")
+	fmt.Println(strings.Repeat("-", 100))
+	fmt.Printf("%-20s %-16s %-5s %-7s %-5s %-7s %-8s %-10s %-10s\n",
+		"DRIVER", "TEAM", "DNFS", "DRIVER", "CAR", "VICTIM", "UNKNOWN", "DRV REL", "CAR REL")
+	fmt.Println(strings.Repeat("-", 100))
+	for _, d := range drvs {
+		var split F1DNFSplitV2
+		var dnfs int
+		if cur, ok := d.BasicData.CurrentSeason(0); ok {
+			split, dnfs = cur.DNFSplit(), cur.DNFs
+		}
+		fmt.Printf("%-20s %-16s %-5d %-7d %-5d %-7d %-8d %-10.2f %-10.2f\n",
+			d.BasicData.Name, d.BasicData.Team, dnfs, split.Driver, split.Car, split.Victim, split.Unknown,
+			d.ConsRaw, d.TeamReliabRaw)
+	}
+	fmt.Println(strings.Repeat("-", 100))
+}
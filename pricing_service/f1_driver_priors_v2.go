@@ -0,0 +1,132 @@
+package pricingservice
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ============================================================
+//  ROOKIE / LOW-DATA PRIORS
+// ============================================================
+//
+// A driver with few F1 races has 3-year aggregates built from a handful of
+// rows (or none at all), which turns into near-zero Z-scores and prices a
+// rookie in a front-running car like a backmarker. Instead we estimate a
+// prior for every 3-year metric and blend it with the observed values:
+//
+//	raw = w·prior + (1−w)·observed      w = 1 − races/PriorFadeRaces  (≥ 0)
+//
+// so a debutant is priced entirely off the prior and the prior fades out
+// linearly as F1 races accrue. When the driver has feeder-series records
+// the prior share of team points comes from the feeder conversion model
+// (see f1_feeder_series_v2.go) instead of the flat RookieTeamShare.
+
+const (
+	defaultPriorFadeRaces  = 24   // one full season
+	defaultRookieTeamShare = 0.40 // expected share of team points for a rookie
+	rookieDNFBump          = 0.03 // extra DNF rate for a driver new to F1
+)
+
+// F1DriverPriorV2 is the prior estimate blended into a driver's 3-year metrics.
+type F1DriverPriorV2 struct {
+	Weight float64 // 0 = observed data only, 1 = prior only
+	Source string  // what the prior was built from: "team" or "feeder+team"
+
+	PPR, WIN, POD, PTF, DNF, SHARE, DELTA, CHAMP float64
+}
+
+// f1Races is the number of F1 races the driver has data for.
+func (b *F1BasicDriverDataV2) f1Races() int {
+	races := 0
+	for _, s := range b.Seasons {
+		races += s.Races
+	}
+	if b.CareerStarts > races {
+		return b.CareerStarts
+	}
+	return races
+}
+
+// priorWeight fades linearly from 1 (no races) to 0 (PriorFadeRaces races).
+func (m *F1QuantumPricingModelV2) priorWeight(b *F1BasicDriverDataV2) float64 {
+	if m.PriorFadeRaces <= 0 {
+		return 0
+	}
+	return clamp(1-float64(b.f1Races())/float64(m.PriorFadeRaces), 0, 1)
+}
+
+// teamPrior estimates the 3-year metrics from the current car alone: a
+// RookieTeamShare slice of what the team scores, wins and podiums per race.
+func (m *F1QuantumPricingModelV2) teamPrior(t *F1TeamDataV2, grid int) seasonAgg {
+	return m.teamPriorWithShare(t, grid, m.RookieTeamShare)
+}
+
+func (m *F1QuantumPricingModelV2) teamPriorWithShare(t *F1TeamDataV2, grid int, share float64) seasonAgg {
+	races := float64(t.CurrentRace)
+	if races == 0 {
+		races = 1
+	}
+	out := seasonAgg{
+		PPR:   share * t.SeasonPoints / races,
+		WIN:   share * float64(t.Wins) / races,
+		POD:   share * float64(t.Podiums) / races,
+		DNF:   float64(t.DNFs)/(2*races) + rookieDNFBump,
+		SHARE: share,
+		DELTA: (2*share - 1) * t.SeasonPoints,
+	}
+	out.PTF = clamp(out.PPR/5, 0, 1) // ≈ one points finish per 5 pts/race
+	if grid > 1 && t.SeasonPosition > 0 {
+		out.CHAMP = 1 - float64(t.SeasonPosition-1)/float64(grid-1)
+	}
+	return out
+}
+
+// attachPrior builds the driver's prior and blends it into the 3-year raws.
+// Must run after store3yRaw.
+func (m *F1QuantumPricingModelV2) attachPrior(d *F1CompleteDriverV2, team *F1TeamDataV2, grid int) {
+	w := m.priorWeight(&d.BasicData)
+	if w == 0 {
+		d.Prior = F1DriverPriorV2{Source: "none"}
+		return
+	}
+	p, src := m.teamPrior(team, grid), "team"
+	if fp, ok := m.feederPrior(&d.BasicData, team, grid); ok {
+		p, src = fp, "feeder+team"
+	}
+	d.Prior = F1DriverPriorV2{
+		Weight: w, Source: src,
+		PPR: p.PPR, WIN: p.WIN, POD: p.POD, PTF: p.PTF,
+		DNF: p.DNF, SHARE: p.SHARE, DELTA: p.DELTA, CHAMP: p.CHAMP,
+	}
+	blend := func(prior, obs float64) float64 { return w*prior + (1-w)*obs }
+	d.PPR3yRaw = blend(p.PPR, d.PPR3yRaw)
+	d.WIN3yRaw = blend(p.WIN, d.WIN3yRaw)
+	d.POD3yRaw = blend(p.POD, d.POD3yRaw)
+	d.PTFIN3yRaw = blend(p.PTF, d.PTFIN3yRaw)
+	d.DNF3yRaw = blend(p.DNF, d.DNF3yRaw)
+	d.SHARE3yRaw = blend(p.SHARE, d.SHARE3yRaw)
+	d.DELTA3yRaw = blend(p.DELTA, d.DELTA3yRaw)
+	d.CHAMP3yRaw = blend(p.CHAMP, d.CHAMP3yRaw)
+}
+
+// PrintDriverPriorsTable lists every driver whose 3-year metrics carry a prior.
+func (m *F1QuantumPricingModelV2) PrintDriverPriorsTable(drvs []*F1CompleteDriverV2) {
+	fmt.Println("\n=== DRIVER PRIORS (ROOKIE / LOW-DATA) ===")
+	fmt.Println(strings.Repeat("-", 80))
+	fmt.Printf("%-20s %-16s %-8s %-8s %-10s %-10s\n",
+		"DRIVER", "TEAM", "RACES", "WEIGHT", "SOURCE", "PRIOR PPR")
+	fmt.Println(strings.Repeat("-", 80))
+	for _, d := range drvs {
+		if d.Prior.Weight == 0 {
+			continue
+		}
+		fmt.Printf("%-20s %-16s %-8d %-8.2f %-10s %-10.2f\n",
+			d.BasicData.Name,
+			d.BasicData.TeamData.Name,
+			d.BasicData.f1Races(),
+			d.Prior.Weight,
+			d.Prior.Source,
+			d.Prior.PPR)
+	}
+	fmt.Println(strings.Repeat("-", 80))
+}
@@ -0,0 +1,69 @@
+package pricingservice
+
+import "testing"
+
+func TestPriorWeightFades(t *testing.T) {
+	m := NewF1QuantumPricingModelV2()
+	tests := []struct {
+		name string
+		b    F1BasicDriverDataV2
+		want float64
+	}{
+		{"debutant", F1BasicDriverDataV2{}, 1},
+		{"half a season", F1BasicDriverDataV2{Seasons: []F1BasicSeasonStatsV2{{Year: 2025, Races: 12}}}, 0.5},
+		{"career starts count", F1BasicDriverDataV2{CareerStarts: 18, Seasons: []F1BasicSeasonStatsV2{{Year: 2025, Races: 6}}}, 0.25},
+		{"veteran", F1BasicDriverDataV2{CareerStarts: 200}, 0},
+	}
+	for _, tt := range tests {
+		if got := m.priorWeight(&tt.b); !approx(got, tt.want) {
+			t.Errorf("%s: priorWeight = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+	m.PriorFadeRaces = 0
+	if got := m.priorWeight(&F1BasicDriverDataV2{}); got != 0 {
+		t.Errorf("priors off: weight %v", got)
+	}
+}
+
+func TestAttachPriorBlends(t *testing.T) {
+	m := NewF1QuantumPricingModelV2()
+	team := &F1TeamDataV2{SeasonPoints: 200, CurrentRace: 10, Wins: 2, Podiums: 6, DNFs: 2, SeasonPosition: 3}
+	rookie := func() *F1CompleteDriverV2 {
+		return &F1CompleteDriverV2{
+			BasicData: F1BasicDriverDataV2{Seasons: []F1BasicSeasonStatsV2{{Year: 2025, Races: 12}}},
+			PPR3yRaw:  4, SHARE3yRaw: 0.2, CHAMP3yRaw: 0.5, DNF3yRaw: 0.1,
+		}
+	}
+
+	d := rookie()
+	m.attachPrior(d, team, 10)
+	// RookieTeamShare 0.4 of the team's 20 pts/race, P3 of 10, blended half-and-half
+	p := d.Prior
+	if p.Source != "team" || !approx(p.Weight, 0.5) || !approx(p.PPR, 8) || !approx(p.CHAMP, 1-2.0/9) {
+		t.Fatalf("team prior = %+v", p)
+	}
+	if !approx(p.DNF, 2.0/20+rookieDNFBump) {
+		t.Errorf("prior DNF = %v, want the per-car rate plus the rookie bump", p.DNF)
+	}
+	if !approx(d.PPR3yRaw, 6) || !approx(d.SHARE3yRaw, 0.3) || !approx(d.CHAMP3yRaw, (0.5+1-2.0/9)/2) {
+		t.Errorf("blended raws PPR %v SHARE %v CHAMP %v", d.PPR3yRaw, d.SHARE3yRaw, d.CHAMP3yRaw)
+	}
+
+	// junior results replace the flat share with the feeder conversion
+	withFeeder := rookie()
+	withFeeder.BasicData.JuniorSeasons = []F1JuniorSeasonV2{
+		{Series: "F2", Year: 2024, Points: 250, Races: 28, Wins: 6, Podiums: 12, ChampionshipPosition: 1},
+	}
+	m.attachPrior(withFeeder, team, 10)
+	if withFeeder.Prior.Source != "feeder+team" || withFeeder.Prior.PPR <= p.PPR {
+		t.Errorf("F2 champion prior = %+v, want a feeder prior above the flat %v", withFeeder.Prior, p.PPR)
+	}
+
+	// enough F1 races: no prior, observed values kept
+	veteran := rookie()
+	veteran.BasicData.CareerStarts = 100
+	m.attachPrior(veteran, team, 10)
+	if veteran.Prior.Source != "none" || veteran.Prior.Weight != 0 || veteran.PPR3yRaw != 4 {
+		t.Errorf("veteran prior = %+v, PPR %v", veteran.Prior, veteran.PPR3yRaw)
+	}
+}
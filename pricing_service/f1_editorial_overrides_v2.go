@@ -0,0 +1,138 @@
+package pricingservice
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ============================================================
+//  EDITORIAL OVERRIDES  (forced prices inside the v2 pricing run)
+// ============================================================
+//
+// Unlike a PriceRuleSet override, which clips the published price after the
+// model has run, an editorial override is applied between the strength
+// score and the budget band: the overridden drivers are fixed first, then
+// solveBand is re-run for everyone else against what is left of the spend
+// target, so the cap constraint still holds for the full grid.
+
+// F1EditorialOverrideV2 forces one driver's price for a window of rounds.
+// Exactly one of Price (absolute, M) or Delta (M added to the model's base
+// price) must be set.
+type F1EditorialOverrideV2 struct {
+	Driver     string
+	Price      float64
+	Delta      float64
+	Reason     string
+	Author     string
+	FromRound  int
+	UntilRound int // inclusive; 0 = open-ended
+}
+
+// LoadEditorialOverridesV2 reads and validates an overrides JSON file.
+func LoadEditorialOverridesV2(path string) ([]F1EditorialOverrideV2, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading overrides file: %v", err)
+	}
+	var out []F1EditorialOverrideV2
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, fmt.Errorf("error unmarshaling overrides: %v", err)
+	}
+	for i, o := range out {
+		if o.Driver == "" {
+			return nil, fmt.Errorf("override %d: driver is required", i)
+		}
+		if (o.Price != 0) == (o.Delta != 0) {
+			return nil, fmt.Errorf("override %d (%s): set exactly one of Price or Delta", i, o.Driver)
+		}
+		if o.Price < 0 {
+			return nil, fmt.Errorf("override %d (%s): negative price %.2f", i, o.Driver, o.Price)
+		}
+		if o.Reason == "" || o.Author == "" {
+			return nil, fmt.Errorf("override %d (%s): reason and author are required", i, o.Driver)
+		}
+	}
+	return out, nil
+}
+
+func (o F1EditorialOverrideV2) activeAt(round int) bool {
+	return round >= o.FromRound && (o.UntilRound == 0 || round <= o.UntilRound)
+}
+
+// price returns the forced price given the driver's model base price.
+func (o F1EditorialOverrideV2) price(base float64) float64 {
+	if o.Price != 0 {
+		return o.Price
+	}
+	return base + o.Delta
+}
+
+// checkEditorialOverrides verifies that every override, active or not,
+// names a driver in drvs, so a misspelt name fails the run instead of
+// silently leaving the driver at the model price.
+func (model *F1QuantumPricingModelV2) checkEditorialOverrides(drvs []*F1CompleteDriverV2) error {
+	names := make(map[string]bool, len(drvs))
+	for _, d := range drvs {
+		names[strings.ToLower(d.BasicData.Name)] = true
+	}
+	for i, o := range model.Overrides {
+		if !names[strings.ToLower(o.Driver)] {
+			return fmt.Errorf("editorial override %d: unknown driver %q", i, o.Driver)
+		}
+	}
+	return nil
+}
+
+// activeEditorialOverrides returns the override in force per driver name.
+// PopulateDriverStats has already rejected overrides for unknown drivers.
+func (model *F1QuantumPricingModelV2) activeEditorialOverrides(drvs []*F1CompleteDriverV2, round int) map[string]F1EditorialOverrideV2 {
+	if len(model.Overrides) == 0 {
+		return nil
+	}
+	names := make(map[string]string, len(drvs))
+	for _, d := range drvs {
+		names[strings.ToLower(d.BasicData.Name)] = d.BasicData.Name
+	}
+	out := make(map[string]F1EditorialOverrideV2)
+	for _, o := range model.Overrides {
+		if !o.activeAt(round) {
+			continue
+		}
+		name, ok := names[strings.ToLower(o.Driver)]
+		if !ok {
+			continue
+		}
+		out[name] = o // later entries win
+	}
+	return out
+}
+
+// editorialLogEntry records an override in the audit log.
+func editorialLogEntry(o F1EditorialOverrideV2, driver string, round int, before, after float64) PriceRuleLogEntry {
+	note := o.Reason
+	if o.Delta != 0 {
+		note = fmt.Sprintf("%s (delta %+.2f)", o.Reason, o.Delta)
+	}
+	return PriceRuleLogEntry{
+		Driver: driver, Round: round, Rule: "editorial-override",
+		Before: before, After: after, Note: note, Author: o.Author,
+	}
+}
+
+// AppendAuditLog appends log entries to a JSON-lines audit file.
+func AppendAuditLog(path string, entries []PriceRuleLogEntry) error {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("error opening audit log: %v", err)
+	}
+	defer f.Close()
+	enc := json.NewEncoder(f)
+	for _, e := range entries {
+		if err := enc.Encode(e); err != nil {
+			return fmt.Errorf("error writing audit log: %v", err)
+		}
+	}
+	return nil
+}
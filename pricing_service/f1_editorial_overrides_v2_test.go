@@ -0,0 +1,27 @@
+package pricingservice
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEditorialOverrideUnknownDriver(t *testing.T) {
+	model := NewF1QuantumPricingModelV2()
+	drvs := model.NewDriverSet(loadGoldenDrivers[F1BasicDriverDataV2](t))
+	teams := model.BuildTeamMapFromDrivers(drvs)
+	name := drvs[0].BasicData.Name
+
+	model.Overrides = []F1EditorialOverrideV2{
+		{Driver: strings.ToUpper(name), Price: 30, Reason: "r", Author: "a"},
+		{Driver: "Max Verstapen", Delta: 1, Reason: "r", Author: "a", FromRound: 20},
+	}
+	err := model.PopulateDriverStats(drvs, teams)
+	if err == nil || !strings.Contains(err.Error(), `"Max Verstapen"`) {
+		t.Fatalf("unknown driver error = %v", err)
+	}
+
+	model.Overrides = model.Overrides[:1]
+	if err := model.PopulateDriverStats(drvs, teams); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,132 @@
+package pricingservice
+
+import (
+	"sort"
+	"strings"
+)
+
+// ============================================================
+//  FEEDER SERIES  (F2 / F3 / … records feeding the rookie prior)
+// ============================================================
+//
+// Junior-category results are turned into a single quality score q ∈ [0,1]
+// per driver:
+//
+//	q_season = strength · (0.40·champPct + 0.25·winRate·3 + 0.15·podRate + 0.20·ptsShare)
+//
+// where ptsShare is the driver's points over the points a win every race
+// would have scored (Races × the series' WinPoints).
+//	q        = mean of q_season weighted by the model's season decay
+//	           (Rate^(k+1): 0.60, 0.36, 0.216 … by default)
+//
+// q then moves the expected share of team points away from the flat
+// RookieTeamShare, so a dominant F2 champion in a midfield car gets a
+// bigger slice of the team prior than a mid-table F3 graduate.
+
+// F1JuniorSeasonV2 is one season in a junior / feeder championship.
+type F1JuniorSeasonV2 struct {
+	Series               string // "F2", "F3", "FRECA", "Super Formula", …
+	Year                 int
+	Team                 string
+	Points               float64
+	Races                int
+	Wins                 int
+	Podiums              int
+	ChampionshipPosition int
+}
+
+// F1FeederSeriesV2 describes how well a junior series translates to F1.
+type F1FeederSeriesV2 struct {
+	Strength  float64 // F2 = 1.0; weaker series scale results down
+	FieldSize int     // full-time drivers, used to turn position into a %
+	WinPoints float64 // points for a race win, used to turn points into a share
+}
+
+// F1FeederConversionV2 maps junior-category performance to expected F1 metrics.
+type F1FeederConversionV2 struct {
+	Series      map[string]F1FeederSeriesV2 // keyed by lower-case series name
+	ShareSpread float64                     // share swing between q = 0 and q = 1
+	DNFSpread   float64                     // DNF-rate swing between q = 0 and q = 1
+	LookBack    int                         // junior seasons considered, newest first
+}
+
+func NewF1FeederConversionV2() F1FeederConversionV2 {
+	return F1FeederConversionV2{
+		Series: map[string]F1FeederSeriesV2{
+			"f2":            {Strength: 1.00, FieldSize: 22, WinPoints: 25},
+			"super formula": {Strength: 0.85, FieldSize: 22, WinPoints: 20},
+			"indycar":       {Strength: 0.75, FieldSize: 27, WinPoints: 50},
+			"f3":            {Strength: 0.65, FieldSize: 30, WinPoints: 25},
+			"freca":         {Strength: 0.45, FieldSize: 34, WinPoints: 25},
+			"f4":            {Strength: 0.25, FieldSize: 30, WinPoints: 25},
+		},
+		ShareSpread: 0.20,
+		DNFSpread:   0.04,
+		LookBack:    3,
+	}
+}
+
+// seasonQuality scores a single junior season on 0-1 before recency weighting.
+func (c *F1FeederConversionV2) seasonQuality(s F1JuniorSeasonV2) (float64, bool) {
+	series, ok := c.Series[strings.ToLower(s.Series)]
+	if !ok || s.Races == 0 {
+		return 0, false
+	}
+	champPct := 0.0
+	if s.ChampionshipPosition > 0 && series.FieldSize > 1 {
+		champPct = clamp(1-float64(s.ChampionshipPosition-1)/float64(series.FieldSize-1), 0, 1)
+	}
+	winRate := float64(s.Wins) / float64(s.Races)
+	podRate := float64(s.Podiums) / float64(s.Races)
+	ptsShare := 0.0
+	if series.WinPoints > 0 {
+		ptsShare = clamp(s.Points/(float64(s.Races)*series.WinPoints), 0, 1)
+	}
+	q := 0.40*champPct + 0.25*clamp(winRate*3, 0, 1) + 0.15*podRate + 0.20*ptsShare
+	return series.Strength * clamp(q, 0, 1), true
+}
+
+// Quality returns the junior quality score, seasons weighted by the
+// model's season decay; ok is false when the driver has no usable junior
+// record.
+func (c *F1FeederConversionV2) Quality(seasons []F1JuniorSeasonV2, decay F1SeasonDecayV2) (q float64, ok bool) {
+	rows := append([]F1JuniorSeasonV2(nil), seasons...)
+	sort.SliceStable(rows, func(i, j int) bool { return rows[i].Year > rows[j].Year })
+	var sumW float64
+	used := 0
+	for _, s := range rows {
+		if used == c.LookBack {
+			break
+		}
+		sq, valid := c.seasonQuality(s)
+		if !valid {
+			continue
+		}
+		w := decay.Weight(used)
+		q += w * sq
+		sumW += w
+		used++
+	}
+	if sumW == 0 {
+		return 0, false
+	}
+	return q / sumW, true
+}
+
+// ExpectedShare converts junior quality into the expected share of team points.
+func (c *F1FeederConversionV2) ExpectedShare(base, q float64) float64 {
+	return clamp(base+(q-0.5)*c.ShareSpread, 0.2, 0.6)
+}
+
+// feederPrior estimates the 3-year metrics from junior results in the current
+// car. Returns false when the driver has no usable junior record.
+func (m *F1QuantumPricingModelV2) feederPrior(b *F1BasicDriverDataV2, t *F1TeamDataV2, grid int) (seasonAgg, bool) {
+	q, ok := m.FeederConversion.Quality(b.JuniorSeasons, m.Decay)
+	if !ok {
+		return seasonAgg{}, false
+	}
+	share := m.FeederConversion.ExpectedShare(m.RookieTeamShare, q)
+	p := m.teamPriorWithShare(t, grid, share)
+	p.DNF = clamp(p.DNF-(q-0.5)*m.FeederConversion.DNFSpread, 0, 1)
+	return p, true
+}
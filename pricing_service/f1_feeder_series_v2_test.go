@@ -0,0 +1,42 @@
+package pricingservice
+
+import "testing"
+
+func TestFeederSeasonQuality(t *testing.T) {
+	c := NewF1FeederConversionV2()
+	// F2 champion: 5 wins and 10 podiums in 20 races, 200 of a possible 500 points
+	s := F1JuniorSeasonV2{Series: "F2", Year: 2024, Races: 20, Wins: 5, Podiums: 10, Points: 200, ChampionshipPosition: 1}
+	want := 0.40*1 + 0.25*0.75 + 0.15*0.5 + 0.20*0.4
+	if q, ok := c.seasonQuality(s); !ok || !approx(q, want) {
+		t.Errorf("F2 champion quality = %v %v, want %v", q, ok, want)
+	}
+
+	// same results with fewer points score lower
+	fewer := s
+	fewer.Points = 100
+	if q, _ := c.seasonQuality(fewer); !approx(q, want-0.20*0.2) {
+		t.Errorf("quality with half the points = %v, want %v", q, want-0.20*0.2)
+	}
+}
+
+func TestFeederQualityUsesSeasonDecay(t *testing.T) {
+	c := NewF1FeederConversionV2()
+	seasons := []F1JuniorSeasonV2{
+		{Series: "F3", Year: 2023, Races: 20, ChampionshipPosition: 15},
+		{Series: "F2", Year: 2024, Races: 28, Wins: 4, Podiums: 9, ChampionshipPosition: 1},
+	}
+	q24, _ := c.seasonQuality(seasons[1])
+	q23, _ := c.seasonQuality(seasons[0])
+
+	decay := NewF1SeasonDecayV2()
+	if q, ok := c.Quality(seasons, decay); !ok || !approx(q, (0.60*q24+0.36*q23)/(0.60+0.36)) {
+		t.Errorf("quality at the default rate = %v %v", q, ok)
+	}
+	decay.Rate = 1
+	if q, _ := c.Quality(seasons, decay); !approx(q, (q24+q23)/2) {
+		t.Errorf("quality at rate 1 = %v, want the plain mean", q)
+	}
+	if _, ok := c.Quality([]F1JuniorSeasonV2{{Series: "Karting", Races: 10}}, decay); ok {
+		t.Errorf("unknown series gave a quality")
+	}
+}
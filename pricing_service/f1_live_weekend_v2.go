@@ -0,0 +1,510 @@
+package pricingservice
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+)
+
+// ============================================================
+//  LIVE RACE WEEKEND  (incremental repricing between sessions)
+// ============================================================
+//
+// F1LiveWeekendV2 keeps a priced grid in memory and takes session results
+// as they arrive. Each event is folded into the drivers' season rows and
+// the team snapshots, then only the stages the event can move are re-run:
+//
+//	qualifying  grid positions held for the race
+//	sprint      3-year roll-ups, team snapshot, champ %
+//	race        live window, 3-year roll-ups, team snapshot and trends,
+//	            champ %, teammate H2H, adaptation
+//	penalty     an amended race classification (Results) or steward
+//	            decisions (Amendments), same stages as race bar adaptation
+//
+// Raw metrics are re-attached for the listed drivers and their teammates
+// only; Z-scores are always re-taken over the full grid. DNA is never
+// touched by an event.
+//
+// Rolling back restores the season data captured before the first event,
+// replays the remaining events and re-runs the stages of the rolled-back
+// event and of every event replayed after it (a race replayed without its
+// qualifying grid has new gains).
+
+// F1SessionKindV2 is the type of a race-weekend event.
+type F1SessionKindV2 string
+
+const (
+	F1QualifyingV2 F1SessionKindV2 = "qualifying"
+	F1SprintV2     F1SessionKindV2 = "sprint"
+	F1RaceV2       F1SessionKindV2 = "race"
+	F1PenaltyV2    F1SessionKindV2 = "penalty"
+)
+
+// F1SessionResultV2 is one driver's line in a session classification. For a
+// penalty event it is the driver's amended race result.
+type F1SessionResultV2 struct {
+	Driver     string
+	Position   int
+	Grid       int // race only; start slot after penalties (0 = qualifying position)
+	Points     float64
+	FastestLap bool
+	DNF        bool
+	DNFCause   F1DNFCauseV2
+	Classified bool // DNF but over 90% distance; finishers are always classified
+
+	GapToLeaderSec float64 // race only; needed for later time penalties
+	LapsDown       int
+}
+
+// F1WeekendEventV2 is one session's results for a round.
+type F1WeekendEventV2 struct {
+	ID       int // assigned by Apply
+	Kind     F1SessionKindV2
+	Round    int
+	RaceName string
+	Results  []F1SessionResultV2
+	Note     string
+
+	// penalty only: steward decisions, applied through ApplyAmendments'
+	// reclassification instead of hand-amended Results
+	Amendments []F1ResultAmendmentV2
+}
+
+// F1WeekendUpdateV2 reports what an Apply or Rollback recomputed and the
+// price sheet that came out of it.
+type F1WeekendUpdateV2 struct {
+	Event      F1WeekendEventV2
+	RolledBack bool
+	Drivers    []string // drivers whose raw metrics were recomputed
+	Stages     []string
+	Prices     []F1DriverPriceV2
+}
+
+// recompute stages, in PopulateDriverStats order
+type weekendStage int
+
+const (
+	stageLive weekendStage = 1 << iota
+	stage3y
+	stageTeam
+	stageChamp
+	stageH2H
+	stageAdapt
+)
+
+func (k F1SessionKindV2) stages() weekendStage {
+	switch k {
+	case F1SprintV2:
+		return stage3y | stageTeam | stageChamp
+	case F1RaceV2:
+		return stageLive | stage3y | stageTeam | stageChamp | stageH2H | stageAdapt
+	case F1PenaltyV2:
+		return stageLive | stage3y | stageTeam | stageChamp | stageH2H
+	}
+	return 0 // qualifying only feeds the race that follows
+}
+
+// F1LiveWeekendV2 is the in-memory pricing state for a race weekend.
+type F1LiveWeekendV2 struct {
+	Model  *F1QuantumPricingModelV2
+	Cap    float64
+	Roster int
+
+	f1SeasonLedgerV2 // live driver set, team snapshots and season year
+
+	base      []F1BasicDriverDataV2 // driver data before the first event
+	published []float64             // prices published before the weekend
+	events    []F1WeekendEventV2
+	nextID    int
+	grids     map[int]map[*F1CompleteDriverV2]int // qualifying positions per round
+}
+
+// NewF1LiveWeekendV2 prices the grid once in full and returns the state
+// that later events are applied to.
+func NewF1LiveWeekendV2(model *F1QuantumPricingModelV2, basics []F1BasicDriverDataV2, cap float64, roster int) (*F1LiveWeekendV2, error) {
+	w := &F1LiveWeekendV2{
+		Model:  model,
+		Cap:    cap,
+		Roster: roster,
+		base:   make([]F1BasicDriverDataV2, len(basics)),
+		nextID: 1,
+		grids:  make(map[int]map[*F1CompleteDriverV2]int),
+	}
+	// NewDriver clones the data and canonicalises its team names; the base
+	// a rollback restores is taken after that
+	drvs := model.NewDriverSet(basics)
+	for i, d := range drvs {
+		w.base[i] = cloneBasicDriverV2(d.BasicData)
+	}
+	// the ledger's year is the model's SeasonYear when set (NewDriver
+	// stamps it on every team snapshot), else the newest driver season
+	w.f1SeasonLedgerV2 = newF1SeasonLedgerV2(drvs, model.BuildTeamMapFromDrivers(drvs))
+	if w.year == 0 {
+		return nil, fmt.Errorf("live weekend: no season to record results in; set the model's SeasonYear")
+	}
+	if err := model.PopulateDriverStats(w.drvs, w.teams); err != nil {
+		return nil, err
+	}
+	w.published = make([]float64, len(w.drvs))
+	for i, d := range w.drvs {
+		w.published[i] = d.Price
+	}
+	return w, nil
+}
+
+// Drivers returns the live driver set.
+func (w *F1LiveWeekendV2) Drivers() []*F1CompleteDriverV2 { return w.drvs }
+
+// Events returns the applied events, oldest first.
+func (w *F1LiveWeekendV2) Events() []F1WeekendEventV2 { return slices.Clone(w.events) }
+
+// Sheet prices the current state. Every sheet of the weekend moves from the
+// prices published before it, so repeated sheets don't compound elasticity.
+func (w *F1LiveWeekendV2) Sheet() []F1DriverPriceV2 {
+	for i, d := range w.drvs {
+		d.Price = w.published[i]
+	}
+	prices := w.Model.PriceDrivers(w.drvs, w.Cap, w.Roster)
+	for i, d := range w.drvs {
+		d.Price = w.published[i]
+	}
+	return prices
+}
+
+// LoadWeekendEventV2 reads one event from a JSON file.
+func LoadWeekendEventV2(path string) (F1WeekendEventV2, error) {
+	var ev F1WeekendEventV2
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ev, fmt.Errorf("error reading event file: %v", err)
+	}
+	if err := json.Unmarshal(data, &ev); err != nil {
+		return ev, fmt.Errorf("error unmarshaling event: %v", err)
+	}
+	return ev, nil
+}
+
+// Apply folds an event into the state, recomputes the affected stages and
+// returns the new price sheet.
+func (w *F1LiveWeekendV2) Apply(ev F1WeekendEventV2) (F1WeekendUpdateV2, error) {
+	if err := w.validate(ev); err != nil {
+		return F1WeekendUpdateV2{}, err
+	}
+	ev.ID = w.nextID
+	w.nextID++
+	w.applyData(ev)
+	w.events = append(w.events, ev)
+	return w.recompute(ev, false, nil), nil
+}
+
+// Rollback removes an applied event, e.g. after a steward decision voids a
+// classification. A race cannot be rolled back while a later penalty
+// amends it; roll the penalty back first.
+func (w *F1LiveWeekendV2) Rollback(id int) (F1WeekendUpdateV2, error) {
+	idx := slices.IndexFunc(w.events, func(e F1WeekendEventV2) bool { return e.ID == id })
+	if idx < 0 {
+		return F1WeekendUpdateV2{}, fmt.Errorf("no event with id %d", id)
+	}
+	ev := w.events[idx]
+	if ev.Kind == F1RaceV2 {
+		for _, later := range w.events[idx+1:] {
+			if later.Kind == F1PenaltyV2 && later.Round == ev.Round {
+				return F1WeekendUpdateV2{}, fmt.Errorf("event %d amends round %d; roll it back first", later.ID, ev.Round)
+			}
+		}
+	}
+	w.events = slices.Delete(w.events, idx, idx+1)
+
+	// restore the pre-weekend data in place (computed metrics are kept)
+	// and replay what is left
+	for i, d := range w.drvs {
+		d.BasicData = cloneBasicDriverV2(w.base[i])
+	}
+	w.teams = w.Model.BuildTeamMapFromDrivers(w.drvs)
+	w.grids = make(map[int]map[*F1CompleteDriverV2]int)
+	for _, e := range w.events {
+		w.applyData(e)
+	}
+	return w.recompute(ev, true, w.events[idx:]), nil
+}
+
+func (w *F1LiveWeekendV2) validate(ev F1WeekendEventV2) error {
+	if ev.Kind.stages() == 0 && ev.Kind != F1QualifyingV2 {
+		return fmt.Errorf("unknown event kind %q", ev.Kind)
+	}
+	if ev.Round <= 0 {
+		return fmt.Errorf("%s event: round is required", ev.Kind)
+	}
+	for _, r := range ev.Results {
+		d, ok := w.byName[strings.ToLower(r.Driver)]
+		if !ok {
+			return fmt.Errorf("%s event round %d: unknown driver %q", ev.Kind, ev.Round, r.Driver)
+		}
+		if ev.Kind == F1PenaltyV2 && w.raceResult(d, ev.Round) == nil {
+			return fmt.Errorf("penalty for %s: no race result for round %d", d.BasicData.Name, ev.Round)
+		}
+		// a second classification would count the round twice; a
+		// changed result goes through a penalty or a rollback
+		if ev.Kind == F1RaceV2 && w.raceResult(d, ev.Round) != nil {
+			return fmt.Errorf("race event round %d: %s already has a result for it; file a penalty or roll the race back", ev.Round, d.BasicData.Name)
+		}
+	}
+	if ev.Kind == F1RaceV2 || ev.Kind == F1SprintV2 {
+		for _, e := range w.events {
+			if e.Kind == ev.Kind && e.Round == ev.Round {
+				return fmt.Errorf("%s event round %d: already recorded as event %d", ev.Kind, ev.Round, e.ID)
+			}
+		}
+	}
+	if len(ev.Amendments) > 0 {
+		if ev.Kind != F1PenaltyV2 {
+			return fmt.Errorf("%s event: amendments belong on a penalty event", ev.Kind)
+		}
+		return w.checkAmendments(ev.Round, ev.roundAmendments())
+	}
+	return nil
+}
+
+// roundAmendments pins the event's amendments to its round.
+func (ev F1WeekendEventV2) roundAmendments() []F1ResultAmendmentV2 {
+	out := slices.Clone(ev.Amendments)
+	for i := range out {
+		out[i].Round = ev.Round
+	}
+	return out
+}
+
+// ------------------------------------------------------------
+//  data updates
+// ------------------------------------------------------------
+
+func (w *F1LiveWeekendV2) applyData(ev F1WeekendEventV2) {
+	switch ev.Kind {
+	case F1QualifyingV2:
+		grid := make(map[*F1CompleteDriverV2]int)
+		for _, r := range ev.Results {
+			grid[w.byName[strings.ToLower(r.Driver)]] = r.Position
+		}
+		w.grids[ev.Round] = grid
+
+	case F1SprintV2:
+		for _, r := range ev.Results {
+			d := w.byName[strings.ToLower(r.Driver)]
+			w.tally(d, F1RaceResultV2{RaceNumber: ev.Round, PointsScored: r.Points}, 1, false)
+		}
+
+	case F1RaceV2:
+		finish := make(map[*F1CompleteDriverV2]int)
+		for _, r := range ev.Results {
+			d := w.byName[strings.ToLower(r.Driver)]
+			finish[d] = r.Position
+			rr := w.raceRow(ev, r, d)
+			s := w.seasonRow(d, ev.Round)
+			s.RecentRaces = append(s.RecentRaces, rr)
+			w.tally(d, rr, 1, true)
+
+			b := &d.BasicData
+			b.CurrentRaceNumber = max(b.CurrentRaceNumber, ev.Round)
+			if b.RacesWithCurrentTeam > 0 {
+				b.RacesWithCurrentTeam++
+			}
+			w.teamOf(d).CurrentRace = max(w.teamOf(d).CurrentRace, ev.Round)
+		}
+		// both trends move together so RaceDrift compares the same rounds
+		w.appendTeamTrend(finish, func(t *F1TeamDataV2) *[]float64 { return &t.RecentRacePositions })
+		if grid, ok := w.grids[ev.Round]; ok {
+			w.appendTeamTrend(grid, func(t *F1TeamDataV2) *[]float64 { return &t.RecentQualifyingPositions })
+		}
+
+	case F1PenaltyV2:
+		if len(ev.Amendments) > 0 {
+			w.amendRound(ev.Round, ev.roundAmendments(), w.Model.Points)
+		}
+		for _, r := range ev.Results {
+			d := w.byName[strings.ToLower(r.Driver)]
+			old := w.raceResult(d, ev.Round)
+			w.tally(d, *old, -1, true)
+			if r.Grid == 0 {
+				r.Grid = old.StartPosition
+			}
+			amended := w.raceRow(ev, r, d)
+			amended.RaceName = old.RaceName
+			amended.QualifyingPosition = old.QualifyingPosition
+			amended.GridPenaltyPlaces = old.GridPenaltyPlaces
+			*w.raceResult(d, ev.Round) = amended
+			w.tally(d, amended, 1, true)
+		}
+	}
+}
+
+// raceRow converts a session line into a stored race result.
+func (w *F1LiveWeekendV2) raceRow(ev F1WeekendEventV2, r F1SessionResultV2, d *F1CompleteDriverV2) F1RaceResultV2 {
+	quali := w.grids[ev.Round][d]
+	grid := r.Grid
+	if grid == 0 {
+		grid = quali
+	}
+	return F1RaceResultV2{
+		RaceName:           ev.RaceName,
+		RaceNumber:         ev.Round,
+		FinishPosition:     r.Position,
+		StartPosition:      grid,
+		QualifyingPosition: quali,
+		PointsScored:       r.Points,
+		FastestLap:         r.FastestLap,
+		DNF:                r.DNF,
+		DNFCause:           r.DNFCause,
+		Classified:         r.Classified || !r.DNF,
+		GapToLeaderSec:     r.GapToLeaderSec,
+		LapsDown:           r.LapsDown,
+		Team:               d.BasicData.Team,
+	}
+}
+
+// appendTeamTrend appends each team's average position in a session to
+// the trend series picked by series, on every driver's copy of the team
+// snapshot so teammates read the same RaceDrift.
+func (w *F1LiveWeekendV2) appendTeamTrend(positions map[*F1CompleteDriverV2]int, series func(*F1TeamDataV2) *[]float64) {
+	sum := make(map[string]float64)
+	n := make(map[string]float64)
+	for d, pos := range positions {
+		if pos <= 0 {
+			continue
+		}
+		key := d.TeamID
+		sum[key] += float64(pos)
+		n[key]++
+	}
+	for _, d := range w.drvs {
+		key := d.TeamID
+		if n[key] > 0 {
+			trend := series(&d.BasicData.TeamData)
+			*trend = appendRecent(*trend, sum[key]/n[key])
+		}
+	}
+}
+
+// appendRecent keeps the last 6 entries, newest last.
+func appendRecent(vals []float64, v float64) []float64 {
+	vals = append(vals, v)
+	if len(vals) > 6 {
+		vals = vals[len(vals)-6:]
+	}
+	return vals
+}
+
+// ------------------------------------------------------------
+//  recompute
+// ------------------------------------------------------------
+
+// recompute re-runs the stages of ev and of the events replayed after it
+// by a rollback, for the drivers they list.
+func (w *F1LiveWeekendV2) recompute(ev F1WeekendEventV2, rolledBack bool, replayed []F1WeekendEventV2) F1WeekendUpdateV2 {
+	evs := append([]F1WeekendEventV2{ev}, replayed...)
+	var stages weekendStage
+	for _, e := range evs {
+		stages |= e.Kind.stages()
+	}
+
+	// listed drivers and their teammates; a reclassification can move anyone
+	seen := make(map[*F1CompleteDriverV2]bool)
+	var sub []*F1CompleteDriverV2
+	for _, e := range evs {
+		if len(e.Amendments) > 0 {
+			for _, d := range w.drvs {
+				seen[d] = true
+			}
+			sub = slices.Clone(w.drvs)
+		}
+	}
+	for _, e := range evs {
+		for _, r := range e.Results {
+			for _, o := range w.teammates(w.byName[strings.ToLower(r.Driver)]) {
+				if !seen[o] {
+					seen[o] = true
+					sub = append(sub, o)
+				}
+			}
+		}
+	}
+
+	up := F1WeekendUpdateV2{Event: ev, RolledBack: rolledBack}
+	if stages != 0 {
+		for _, d := range sub {
+			up.Drivers = append(up.Drivers, d.BasicData.Name)
+		}
+	}
+	run := func(name string, f func()) {
+		f()
+		up.Stages = append(up.Stages, name)
+	}
+
+	if stages&stageLive != 0 {
+		run("live raw", func() { attachLiveRaws(sub) })
+	}
+	// the shrinkage stage reads damped Zs, so they are re-taken first
+	if stages&(stageLive|stage3y|stageTeam) != 0 {
+		run("live Z", func() { computeLiveZ(w.drvs) })
+	}
+	if stages&stage3y != 0 {
+		run("3y raw", func() { w.Model.attach3yRaws(sub, w.teams) })
+		run("3y Z", func() { compute3yZ(w.drvs) })
+	}
+	if stages&(stageLive|stage3y|stageTeam) != 0 {
+		run("shrinkage", func() { w.Model.computeShrinkage(w.drvs, w.teams) })
+	}
+	if stages&stageTeam != 0 {
+		run("team", func() { w.Model.computeTeamStats(w.drvs, w.teams) })
+	}
+	if stages&stageChamp != 0 {
+		run("champ %", func() {
+			AttachChampPctRaw(w.drvs)
+			ComputeChampPctZ(w.drvs)
+		})
+	}
+	if stages&stageH2H != 0 {
+		run("teammate H2H", func() { ComputeTeammateH2H(w.drvs, w.Model.Teams) })
+	}
+	if stages&stageAdapt != 0 {
+		run("adaptation", func() { w.Model.attachAdaptations(sub) })
+	}
+
+	up.Prices = w.Sheet()
+	return up
+}
+
+// PrintWeekendUpdate prints what an event changed and the new sheet.
+func (w *F1LiveWeekendV2) PrintWeekendUpdate(up F1WeekendUpdateV2) {
+	verb := "APPLIED"
+	if up.RolledBack {
+		verb = "ROLLED BACK"
+	}
+	fmt.Printf("\n=== EVENT %d %s: %s round %d %s ===\n", up.Event.ID, verb, up.Event.Kind, up.Event.Round, up.Event.RaceName)
+	if up.Event.Note != "" {
+		fmt.Println(up.Event.Note)
+	}
+	stages := "none (grid held for the race)"
+	if len(up.Stages) > 0 {
+		stages = strings.Join(up.Stages, ", ")
+	}
+	fmt.Printf("Recomputed stages: %s\n", stages)
+	fmt.Printf("Drivers recomputed: %d of %d\n", len(up.Drivers), len(w.drvs))
+	w.Model.PrintDriverPrices(up.Prices)
+}
+
+// cloneBasicDriverV2 deep-copies the parts of a driver that events mutate.
+func cloneBasicDriverV2(b F1BasicDriverDataV2) F1BasicDriverDataV2 {
+	b.Seasons = slices.Clone(b.Seasons)
+	for i := range b.Seasons {
+		b.Seasons[i].RecentRaces = slices.Clone(b.Seasons[i].RecentRaces)
+		b.Seasons[i].DNFCauses = maps.Clone(b.Seasons[i].DNFCauses)
+	}
+	t := &b.TeamData
+	t.SeasonHistory = slices.Clone(t.SeasonHistory)
+	t.RecentRacePositions = slices.Clone(t.RecentRacePositions)
+	t.RecentQualifyingPositions = slices.Clone(t.RecentQualifyingPositions)
+	return b
+}
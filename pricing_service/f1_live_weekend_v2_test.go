@@ -0,0 +1,154 @@
+package pricingservice
+
+import "testing"
+
+// weekendDNFCauses is the mechanical count of the driver's live season row.
+func weekendDNFCauses(t *testing.T, w *F1LiveWeekendV2, name string) int {
+	t.Helper()
+	d, ok := w.driver(name)
+	if !ok {
+		t.Fatalf("no driver %q", name)
+	}
+	cur, ok := d.BasicData.CurrentSeason(w.year)
+	if !ok {
+		t.Fatalf("%s has no %d season", name, w.year)
+	}
+	return cur.DNFCauses[F1MechanicalDNFV2]
+}
+
+func TestWeekendRollbackKeepsDNFCauses(t *testing.T) {
+	basics := loadGoldenDrivers[F1BasicDriverDataV2](t)
+	b := &basics[0]
+	for i := range b.Seasons {
+		if b.Seasons[i].Year == b.SeasonYear() {
+			b.Seasons[i].DNFCauses = map[F1DNFCauseV2]int{F1MechanicalDNFV2: 1}
+		}
+	}
+
+	w, err := NewF1LiveWeekendV2(NewF1QuantumPricingModelV2(), basics, 50, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	round := b.CurrentRaceNumber + 1
+	race, err := w.Apply(F1WeekendEventV2{Kind: F1RaceV2, Round: round, Results: []F1SessionResultV2{
+		{Driver: b.Name, Position: 20, DNF: true, DNFCause: F1MechanicalDNFV2},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sprint, err := w.Apply(F1WeekendEventV2{Kind: F1SprintV2, Round: round, Results: []F1SessionResultV2{{Driver: b.Name, Position: 1, Points: 8}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := weekendDNFCauses(t, w, b.Name); got != 2 {
+		t.Fatalf("mechanical DNFs after the race = %d, want 2", got)
+	}
+
+	// replaying the race on the restored base must not count it twice
+	if _, err := w.Rollback(sprint.Event.ID); err != nil {
+		t.Fatal(err)
+	}
+	if got := weekendDNFCauses(t, w, b.Name); got != 2 {
+		t.Errorf("mechanical DNFs after rolling back the sprint = %d, want 2", got)
+	}
+	if _, err := w.Rollback(race.Event.ID); err != nil {
+		t.Fatal(err)
+	}
+	if got := weekendDNFCauses(t, w, b.Name); got != 1 {
+		t.Errorf("mechanical DNFs after rolling back the race = %d, want 1", got)
+	}
+	for _, s := range basics[0].Seasons {
+		if n := s.DNFCauses[F1MechanicalDNFV2]; s.Year == b.SeasonYear() && n != 1 {
+			t.Errorf("caller's DNF causes changed to %d", n)
+		}
+	}
+}
+
+func TestWeekendRollbackQualifyingRecomputesGain(t *testing.T) {
+	basics := loadGoldenDrivers[F1BasicDriverDataV2](t)
+	a, b := basics[0].Name, basics[1].Name
+	round := basics[0].CurrentRaceNumber + 1
+	quali := F1WeekendEventV2{Kind: F1QualifyingV2, Round: round, Results: []F1SessionResultV2{
+		{Driver: a, Position: 18}, {Driver: b, Position: 2},
+	}}
+	race := F1WeekendEventV2{Kind: F1RaceV2, Round: round, Results: []F1SessionResultV2{
+		{Driver: a, Position: 1, Points: 25}, {Driver: b, Position: 2, Points: 18},
+	}}
+
+	w, err := NewF1LiveWeekendV2(NewF1QuantumPricingModelV2(), basics, 50, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q, _ := w.Apply(quali)
+	if _, err := w.Apply(race); err != nil {
+		t.Fatal(err)
+	}
+	up, err := w.Rollback(q.Event.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the same state reached without the qualifying session at all
+	ref, err := NewF1LiveWeekendV2(NewF1QuantumPricingModelV2(), basics, 50, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, _ := ref.Apply(race)
+	for i, d := range w.Drivers() {
+		if r := ref.Drivers()[i]; d.GainRaw != r.GainRaw {
+			t.Errorf("%s gain after rollback = %v, want %v", d.BasicData.Name, d.GainRaw, r.GainRaw)
+		}
+		if up.Prices[i].Price != want.Prices[i].Price {
+			t.Errorf("%s price after rollback = %v, want %v", d.BasicData.Name, up.Prices[i].Price, want.Prices[i].Price)
+		}
+	}
+}
+
+func TestWeekendRejectsRepeatedRace(t *testing.T) {
+	basics := loadGoldenDrivers[F1BasicDriverDataV2](t)
+	a := basics[0]
+	w, err := NewF1LiveWeekendV2(NewF1QuantumPricingModelV2(), basics, 50, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	round := a.CurrentRaceNumber + 1
+	race := F1WeekendEventV2{Kind: F1RaceV2, Round: round, Results: []F1SessionResultV2{{Driver: a.Name, Position: 1, Points: 25}}}
+	first, err := w.Apply(race)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Apply(race); err == nil {
+		t.Errorf("a second race event for round %d was accepted", round)
+	}
+	if cur, ok := a.CurrentSeason(0); ok && len(cur.RecentRaces) > 0 {
+		old := F1WeekendEventV2{Kind: F1RaceV2, Round: cur.RecentRaces[0].RaceNumber, Results: race.Results}
+		if _, err := w.Apply(old); err == nil {
+			t.Errorf("a race event for the recorded round %d was accepted", old.Round)
+		}
+	} else {
+		t.Fatalf("%s has no recorded races", a.Name)
+	}
+	if got := w.Sheet(); got[0].Price != first.Prices[0].Price || len(w.Events()) != 1 {
+		t.Errorf("a rejected event changed the state: %d events", len(w.Events()))
+	}
+}
+
+func TestWeekendSeasonYear(t *testing.T) {
+	model := NewF1QuantumPricingModelV2()
+	model.SeasonYear = 2030
+	w, err := NewF1LiveWeekendV2(model, loadGoldenDrivers[F1BasicDriverDataV2](t), 50, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w.year != 2030 {
+		t.Errorf("weekend year = %d, want the model's 2030", w.year)
+	}
+
+	basics := loadGoldenDrivers[F1BasicDriverDataV2](t)
+	for i := range basics {
+		basics[i].Seasons, basics[i].TeamData.Year = nil, 0
+	}
+	if _, err := NewF1LiveWeekendV2(NewF1QuantumPricingModelV2(), basics, 50, 2); err == nil {
+		t.Errorf("a weekend with no season year was created")
+	}
+}
@@ -0,0 +1,210 @@
+package pricingservice
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ============================================================
+//  OWNERSHIP DEMAND  (pick rate & transfers in the price change)
+// ============================================================
+//
+// Performance alone sets the base price. With ownership data the price
+// change step also follows the market: each driver's pick rate and net
+// transfers over the last round are Z-scored across the grid and turned
+// into a move in M,
+//
+//	demand = clamp(PickWeight·Z(pick) + TransferWeight·Z(net), −MaxFall, +MaxRise)
+//
+// added on top of the elastic move:
+//
+//	published = prev + e·(base − prev) + demand
+//
+// The demand is part of the published price the band is solved against,
+// so it reshuffles the spend between drivers rather than inflating it.
+// First-time prices (no previous) and drivers with no ownership row get
+// no demand move.
+
+const (
+	defaultDemandPickWeight     = 0.10
+	defaultDemandTransferWeight = 0.20
+	defaultDemandMaxRise        = 0.30
+	defaultDemandMaxFall        = 0.30
+)
+
+// F1OwnershipV2 is one driver's selection activity over one round.
+type F1OwnershipV2 struct {
+	Driver       string
+	Round        int
+	PickRate     float64 // share of teams holding the driver (0-1)
+	NetTransfers float64 // transfers in − transfers out
+}
+
+// F1DemandConfigV2 sets the size and limits of the demand move.
+type F1DemandConfigV2 struct {
+	PickWeight     float64 // M per pick-rate Z
+	TransferWeight float64 // M per net-transfer Z
+	MaxRise        float64 // largest demand rise per round (M)
+	MaxFall        float64 // largest demand fall per round (M, positive)
+}
+
+func NewF1DemandConfigV2() F1DemandConfigV2 {
+	return F1DemandConfigV2{
+		PickWeight:     defaultDemandPickWeight,
+		TransferWeight: defaultDemandTransferWeight,
+		MaxRise:        defaultDemandMaxRise,
+		MaxFall:        defaultDemandMaxFall,
+	}
+}
+
+// F1DemandV2 is the demand signal and price move of one driver.
+type F1DemandV2 struct {
+	Round        int // ownership round used (0 = no data)
+	PickRate     float64
+	NetTransfers float64
+	PickZ        float64
+	TransferZ    float64
+	Adjustment   float64 // M added in the price change step
+}
+
+// LoadOwnershipCSV reads ownership rows from a CSV with a header of
+// driver, round, pick_rate, net_transfers (any order, case-insensitive).
+// A pick rate above 1 is read as a percentage.
+func LoadOwnershipCSV(path string) ([]F1OwnershipV2, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("error opening ownership CSV: %v", err)
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	header, err := r.Read()
+	if err != nil {
+		return nil, fmt.Errorf("error reading ownership CSV header: %v", err)
+	}
+	col := map[string]int{}
+	for i, h := range header {
+		col[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+	for _, name := range []string{"driver", "round", "pick_rate", "net_transfers"} {
+		if _, ok := col[name]; !ok {
+			return nil, fmt.Errorf("ownership CSV: missing column %q", name)
+		}
+	}
+
+	var out []F1OwnershipV2
+	for line := 2; ; line++ {
+		rec, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error reading ownership CSV line %d: %v", line, err)
+		}
+		o := F1OwnershipV2{Driver: strings.TrimSpace(rec[col["driver"]])}
+		if o.Driver == "" {
+			return nil, fmt.Errorf("ownership CSV line %d: driver is required", line)
+		}
+		if o.Round, err = strconv.Atoi(strings.TrimSpace(rec[col["round"]])); err != nil || o.Round < 1 {
+			return nil, fmt.Errorf("ownership CSV line %d: invalid round %q", line, rec[col["round"]])
+		}
+		if o.PickRate, err = strconv.ParseFloat(strings.TrimSpace(rec[col["pick_rate"]]), 64); err != nil || o.PickRate < 0 || o.PickRate > 100 {
+			return nil, fmt.Errorf("ownership CSV line %d: invalid pick rate %q", line, rec[col["pick_rate"]])
+		}
+		if o.PickRate > 1 {
+			o.PickRate /= 100
+		}
+		if o.NetTransfers, err = strconv.ParseFloat(strings.TrimSpace(rec[col["net_transfers"]]), 64); err != nil {
+			return nil, fmt.Errorf("ownership CSV line %d: invalid net transfers %q", line, rec[col["net_transfers"]])
+		}
+		out = append(out, o)
+	}
+	return out, nil
+}
+
+// attachDemand sets each driver's Demand from the newest ownership round
+// before the round being priced.
+func (model *F1QuantumPricingModelV2) attachDemand(drvs []*F1CompleteDriverV2, round int) {
+	for _, d := range drvs {
+		d.Demand = F1DemandV2{}
+	}
+	used := 0
+	for _, o := range model.Ownership {
+		if o.Round < round && o.Round > used {
+			used = o.Round
+		}
+	}
+	if used == 0 {
+		return
+	}
+
+	byName := make(map[string]F1OwnershipV2)
+	for _, o := range model.Ownership {
+		if o.Round == used {
+			byName[strings.ToLower(o.Driver)] = o
+		}
+	}
+	var with []*F1CompleteDriverV2
+	var picks, nets []float64
+	for _, d := range drvs {
+		o, ok := byName[strings.ToLower(d.BasicData.Name)]
+		if !ok {
+			continue
+		}
+		d.Demand = F1DemandV2{Round: used, PickRate: o.PickRate, NetTransfers: o.NetTransfers}
+		with = append(with, d)
+		picks = append(picks, o.PickRate)
+		nets = append(nets, o.NetTransfers)
+	}
+	if len(with) < 2 {
+		return
+	}
+
+	cfg := model.Demand
+	pMu, pSD := meanStd(picks)
+	nMu, nSD := meanStd(nets)
+	for i, d := range with {
+		if pSD > 0 {
+			d.Demand.PickZ = clamp((picks[i]-pMu)/pSD, -3, 3)
+		}
+		if nSD > 0 {
+			d.Demand.TransferZ = clamp((nets[i]-nMu)/nSD, -3, 3)
+		}
+		if d.Price == 0 {
+			continue // no price change step on a first price
+		}
+		move := cfg.PickWeight*d.Demand.PickZ + cfg.TransferWeight*d.Demand.TransferZ
+		d.Demand.Adjustment = clamp(move, -cfg.MaxFall, cfg.MaxRise)
+	}
+}
+
+// PrintDemandTable lists the drivers with ownership data, biggest demand
+// move first.
+func PrintDemandTable(drvs []*F1CompleteDriverV2) {
+	var with []*F1CompleteDriverV2
+	for _, d := range drvs {
+		if d.Demand.Round > 0 {
+			with = append(with, d)
+		}
+	}
+	if len(with) == 0 {
+		return
+	}
+	sort.SliceStable(with, func(i, j int) bool { return with[i].Demand.Adjustment > with[j].Demand.Adjustment })
+
+	fmt.Printf("\n=== OWNERSHIP DEMAND (round %d) ===\n", with[0].Demand.Round)
+	fmt.Println(strings.Repeat("-", 80))
+	fmt.Printf("%-20s %-10s %-8s %-12s %-8s %-10s\n", "DRIVER", "PICK %", "PICK Z", "NET TRANS", "NET Z", "DEMAND")
+	fmt.Println(strings.Repeat("-", 80))
+	for _, d := range with {
+		dm := d.Demand
+		fmt.Printf("%-20s %-10.1f %-+8.2f %-+12.0f %-+8.2f %-+10.2f\n",
+			d.BasicData.Name, dm.PickRate*100, dm.PickZ, dm.NetTransfers, dm.TransferZ, dm.Adjustment)
+	}
+	fmt.Println(strings.Repeat("-", 80))
+}
@@ -0,0 +1,81 @@
+package pricingservice
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeOwnershipCSV(t *testing.T, body string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "ownership.csv")
+	if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadOwnershipCSV(t *testing.T) {
+	rows, err := LoadOwnershipCSV(writeOwnershipCSV(t, "Round,Driver,Net_Transfers,Pick_Rate\n3,A,1500,42.5\n3,B,-200,0.10\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 2 || rows[0] != (F1OwnershipV2{Driver: "A", Round: 3, PickRate: 0.425, NetTransfers: 1500}) {
+		t.Errorf("rows = %+v", rows)
+	}
+	if rows[1].PickRate != 0.10 {
+		t.Errorf("fractional pick rate = %v, want 0.10", rows[1].PickRate)
+	}
+
+	if _, err := LoadOwnershipCSV(writeOwnershipCSV(t, "driver,round,pick_rate\nA,3,40\n")); err == nil || !strings.Contains(err.Error(), "net_transfers") {
+		t.Errorf("missing column error = %v", err)
+	}
+	if _, err := LoadOwnershipCSV(writeOwnershipCSV(t, "driver,round,pick_rate,net_transfers\nA,0,40,1\n")); err == nil {
+		t.Errorf("round 0 accepted")
+	}
+}
+
+func TestAttachDemand(t *testing.T) {
+	model := NewF1QuantumPricingModelV2()
+	model.Ownership = []F1OwnershipV2{
+		{Driver: "A", Round: 4, PickRate: 0.6, NetTransfers: 9000},
+		{Driver: "B", Round: 4, PickRate: 0.2, NetTransfers: -3000},
+		{Driver: "C", Round: 4, PickRate: 0.1, NetTransfers: -6000},
+		{Driver: "A", Round: 5, PickRate: 0.1, NetTransfers: -9000}, // not yet raced: ignored for round 5
+		{Driver: "A", Round: 3, PickRate: 0.9, NetTransfers: 0},
+	}
+	a := &F1CompleteDriverV2{BasicData: F1BasicDriverDataV2{Name: "A"}, Price: 20}
+	b := &F1CompleteDriverV2{BasicData: F1BasicDriverDataV2{Name: "b"}, Price: 15}
+	c := &F1CompleteDriverV2{BasicData: F1BasicDriverDataV2{Name: "C"}} // first price
+	d := &F1CompleteDriverV2{BasicData: F1BasicDriverDataV2{Name: "D"}, Price: 10}
+	model.attachDemand([]*F1CompleteDriverV2{a, b, c, d}, 5)
+
+	if a.Demand.Round != 4 || a.Demand.PickRate != 0.6 {
+		t.Fatalf("A demand = %+v, want the round 4 row", a.Demand)
+	}
+	if a.Demand.Adjustment != model.Demand.MaxRise {
+		t.Errorf("A adjustment = %v, want capped at %v", a.Demand.Adjustment, model.Demand.MaxRise)
+	}
+	want := model.Demand.PickWeight*b.Demand.PickZ + model.Demand.TransferWeight*b.Demand.TransferZ
+	if !approx(b.Demand.Adjustment, want) || b.Demand.Adjustment >= 0 {
+		t.Errorf("B adjustment = %v, want %v (< 0)", b.Demand.Adjustment, want)
+	}
+	if c.Demand.Adjustment != 0 || c.Demand.PickZ >= 0 {
+		t.Errorf("C (first price) demand = %+v, want Z set and no move", c.Demand)
+	}
+	if d.Demand != (F1DemandV2{}) {
+		t.Errorf("D has no ownership row but demand = %+v", d.Demand)
+	}
+}
+
+func TestDemandMovesPublishedPrice(t *testing.T) {
+	model := NewF1QuantumPricingModelV2()
+	model.Demand.MaxFall = 0.1
+	d := &F1CompleteDriverV2{Price: 20, ConsRaw: 1}
+	steady := model.publishedPrice(d, 10, 30)
+	d.Demand.Adjustment = -0.1
+	if got := model.publishedPrice(d, 10, 30); !approx(got, steady-0.1) {
+		t.Errorf("published with demand = %v, want %v", got, steady-0.1)
+	}
+}
@@ -0,0 +1,259 @@
+package pricingservice
+
+import (
+	"encoding/csv"
+	"fmt"
+	"math"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gonum.org/v1/gonum/stat"
+	"gonum.org/v1/gonum/stat/distuv"
+)
+
+// ============================================================
+//  POINTS PROJECTION  (expected points next to the price)
+// ============================================================
+//
+// The price says how strong a driver is; the projection says what they
+// are expected to score, in the points of the data (RecentRaces
+// PointsScored), for the next round and the rest of the season.
+//
+// Per-race rate, from three driver estimates blended by how much data
+// backs each one:
+//
+//	season = ChampPctRaw × leader points per race     (season to date)
+//	base   = cS·season + (1 − cS)·PPR3yRaw            cS = n ÷ (n + SeasonCredK)
+//	driver = cL·RecRaw + (1 − cL)·base                cL = rows ÷ (rows + LiveCredK)
+//
+// then pulled towards the car and tilted by its trend:
+//
+//	car  = TeamStrengthRaw × points awarded per race ÷ 2
+//	rate = ((1 − TeamWeight)·driver + TeamWeight·car) · (1 + MomentumTilt·(trend − 1))
+//
+// where trend = current projected team points ÷ MomentumRaw, clamped.
+//
+// Uncertainty: the per-race spread is the sd of the driver's last races
+// (DNFs included), credibility-blended with a prior spread; the rest of
+// season adds the uncertainty of the rate itself, which does not average
+// out over the remaining races:
+//
+//	sd_ros = √(R·sd² + R²·sd²/(rows + LiveCredK))
+
+const (
+	defaultProjectionLiveCredK    = 3.0
+	defaultProjectionSeasonCredK  = 6.0
+	defaultProjectionTeamWeight   = 0.20
+	defaultProjectionMomentumTilt = 0.50
+	defaultProjectionBand         = 0.80
+	projectionSpreadRaces         = 5 // races in the per-race spread
+)
+
+// F1ProjectionConfigV2 tunes the points projection.
+type F1ProjectionConfigV2 struct {
+	LiveCredK    float64 // live-window rows at which RecRaw and the base weigh the same
+	SeasonCredK  float64 // season races at which season-to-date and PPR3y weigh the same
+	TeamWeight   float64 // pull of the rate towards the car's share of the points
+	MomentumTilt float64 // share of the team's points trend passed on to the rate
+	Band         float64 // central probability of the uncertainty band (0.80 = P10–P90)
+}
+
+func NewF1ProjectionConfigV2() F1ProjectionConfigV2 {
+	return F1ProjectionConfigV2{
+		LiveCredK:    defaultProjectionLiveCredK,
+		SeasonCredK:  defaultProjectionSeasonCredK,
+		TeamWeight:   defaultProjectionTeamWeight,
+		MomentumTilt: defaultProjectionMomentumTilt,
+		Band:         defaultProjectionBand,
+	}
+}
+
+// F1PointsProjectionV2 is one driver's expected points and their band.
+type F1PointsProjectionV2 struct {
+	Rate      float64 // expected points per race
+	RaceSD    float64 // sd of one race's points
+	Next      float64 // expected points next round
+	NextLow   float64
+	NextHigh  float64
+	Remaining int // races left including the next one (0 = calendar unknown)
+	ROS       float64
+	ROSLow    float64
+	ROSHigh   float64
+
+	// Value per million of the published price (0 when unpriced)
+	NextPerM float64
+	ROSPerM  float64
+}
+
+// withPrice fills the value-per-million figures.
+func (p F1PointsProjectionV2) withPrice(price float64) F1PointsProjectionV2 {
+	if price > 0 {
+		p.NextPerM = p.Next / price
+		p.ROSPerM = p.ROS / price
+	}
+	return p
+}
+
+// racePool is the points handed out per race (both seats of every team
+// share it, hence the car estimate halves it).
+func (p F1PointsSystemV2) racePool() float64 {
+	var sum float64
+	for _, pts := range p.Positions {
+		sum += pts
+	}
+	return sum + p.FastestLap
+}
+
+// leaderPace is the championship leader's points per race, the scale of
+// ChampPctRaw.
+func leaderPace(drvs []*F1CompleteDriverV2) float64 {
+	var pace, pts float64
+	for _, d := range drvs {
+		cur, ok := d.BasicData.CurrentSeason(0)
+		if ok && cur.Points > pts && cur.Races > 0 {
+			pts, pace = cur.Points, cur.PPR()
+		}
+	}
+	return pace
+}
+
+// seasonCalendar returns the current round and season length from the
+// team snapshot, falling back to the driver's own fields.
+func seasonCalendar(b *F1BasicDriverDataV2) (current, total int) {
+	current, total = b.TeamData.CurrentRace, b.TeamData.TotalRaces
+	if current == 0 {
+		current = b.CurrentRaceNumber
+	}
+	if total == 0 {
+		total = b.TotalRacesInSeason
+	}
+	return current, total
+}
+
+// recentPoints returns the points of the newest ≤n races of the current
+// season, classified or not.
+func recentPoints(b *F1BasicDriverDataV2, n int) []float64 {
+	cur, ok := b.CurrentSeason(0)
+	if !ok {
+		return nil
+	}
+	races := slices.Clone(cur.RecentRaces)
+	sort.SliceStable(races, func(i, j int) bool { return races[i].RaceNumber > races[j].RaceNumber })
+	out := make([]float64, 0, n)
+	for _, rr := range races {
+		if len(out) == n {
+			break
+		}
+		out = append(out, rr.PointsScored)
+	}
+	return out
+}
+
+// ProjectPoints projects every driver's points (drivers already through
+// PopulateDriverStats). Value per million is left at 0 until priced.
+func (model *F1QuantumPricingModelV2) ProjectPoints(drvs []*F1CompleteDriverV2) []F1PointsProjectionV2 {
+	cfg := model.Projection
+	pace := leaderPace(drvs)
+	pool := model.Points.racePool()
+	z := distuv.UnitNormal.Quantile(0.5 + clamp(cfg.Band, 0, 0.998)/2)
+
+	out := make([]F1PointsProjectionV2, len(drvs))
+	for i, d := range drvs {
+		b := &d.BasicData
+		cur, _ := b.CurrentSeason(0)
+
+		// per-race rate
+		cS := float64(cur.Races) / (float64(cur.Races) + cfg.SeasonCredK)
+		base := cS*d.ChampPctRaw*pace + (1-cS)*d.PPR3yRaw
+		cL := float64(d.Rows) / (float64(d.Rows) + cfg.LiveCredK)
+		driver := cL*d.RecRaw + (1-cL)*base
+
+		car := d.TeamStrengthRaw * pool / 2
+		trend := 1.0
+		if d.MomentumRaw > 0 {
+			trend = clamp(projectSeasonPts(&b.TeamData)/d.MomentumRaw, 0.8, 1.25)
+		}
+		rate := ((1-cfg.TeamWeight)*driver + cfg.TeamWeight*car) * (1 + cfg.MomentumTilt*(trend-1))
+		rate = math.Max(rate, 0)
+
+		// per-race spread: recent races against a prior that grows with the rate
+		prior := math.Max(2, 0.8*rate)
+		sd := prior
+		if pts := recentPoints(b, projectionSpreadRaces); len(pts) > 1 {
+			c := float64(len(pts)) / (float64(len(pts)) + cfg.LiveCredK)
+			own := stat.StdDev(pts, nil)
+			sd = math.Sqrt(c*own*own + (1-c)*prior*prior)
+		}
+
+		p := F1PointsProjectionV2{Rate: rate, RaceSD: sd, Next: rate}
+		p.NextLow, p.NextHigh = math.Max(0, rate-z*sd), rate+z*sd
+		if current, total := seasonCalendar(b); total > 0 {
+			p.Remaining = max(total-current, 0)
+		}
+		if r := float64(p.Remaining); r > 0 {
+			rosSD := math.Sqrt(r*sd*sd + r*r*sd*sd/(float64(d.Rows)+cfg.LiveCredK))
+			p.ROS = r * rate
+			p.ROSLow, p.ROSHigh = math.Max(0, p.ROS-z*rosSD), p.ROS+z*rosSD
+		}
+		out[i] = p
+	}
+	return out
+}
+
+// PrintProjectionTable prints expected points and value per million,
+// best next-round value first.
+func PrintProjectionTable(prices []F1DriverPriceV2) {
+	fmt.Println("\n=== EXPECTED POINTS & VALUE (V2) ===")
+	fmt.Println(strings.Repeat("-", 104))
+	fmt.Printf("%-20s %-9s %-7s %-14s %-4s %-8s %-16s %-9s %-9s\n",
+		"DRIVER", "PRICE", "NEXT", "NEXT BAND", "LEFT", "ROS", "ROS BAND", "NEXT/$M", "ROS/$M")
+	fmt.Println(strings.Repeat("-", 104))
+	sorted := append([]F1DriverPriceV2(nil), prices...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Projection.NextPerM > sorted[j].Projection.NextPerM
+	})
+	for _, p := range sorted {
+		pr := p.Projection
+		fmt.Printf("%-20s %-9s %-7.2f %-14s %-4d %-8.1f %-16s %-9.3f %-9.3f\n",
+			p.Driver.BasicData.Name,
+			fmt.Sprintf("$%.1fM", p.Price),
+			pr.Next, fmt.Sprintf("%.1f–%.1f", pr.NextLow, pr.NextHigh),
+			pr.Remaining, pr.ROS, fmt.Sprintf("%.0f–%.0f", pr.ROSLow, pr.ROSHigh),
+			pr.NextPerM, pr.ROSPerM)
+	}
+	fmt.Println(strings.Repeat("-", 104))
+}
+
+// WritePriceSheetCSV writes the published sheet with each driver's
+// projection, one row per driver in sheet order.
+func WritePriceSheetCSV(path string, prices []F1DriverPriceV2) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("error creating price sheet CSV: %v", err)
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	ff := func(x float64, prec int) string { return strconv.FormatFloat(x, 'f', prec, 64) }
+	rows := [][]string{{
+		"driver", "team", "price", "previous_price", "strength",
+		"exp_points_next", "next_low", "next_high", "races_left",
+		"exp_points_ros", "ros_low", "ros_high", "points_per_m_next", "points_per_m_ros",
+	}}
+	for _, p := range prices {
+		pr := p.Projection
+		rows = append(rows, []string{
+			p.Driver.BasicData.Name, p.Driver.BasicData.TeamData.Name,
+			ff(p.Price, 2), ff(p.ComponentBreakdown["Previous Price"], 2), ff(p.Driver.Strength, 4),
+			ff(pr.Next, 2), ff(pr.NextLow, 2), ff(pr.NextHigh, 2), strconv.Itoa(pr.Remaining),
+			ff(pr.ROS, 1), ff(pr.ROSLow, 1), ff(pr.ROSHigh, 1), ff(pr.NextPerM, 4), ff(pr.ROSPerM, 4),
+		})
+	}
+	if err := w.WriteAll(rows); err != nil {
+		return fmt.Errorf("error writing price sheet CSV: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package pricingservice
+
+import (
+	"math"
+	"testing"
+)
+
+func projectionDriver() *F1CompleteDriverV2 {
+	return &F1CompleteDriverV2{
+		BasicData: F1BasicDriverDataV2{
+			Name:     "A",
+			TeamData: F1TeamDataV2{Name: "Red", CurrentRace: 6, TotalRaces: 24, SeasonPoints: 120},
+			Seasons: []F1BasicSeasonStatsV2{{Year: 2025, Team: "Red", Races: 6, Points: 60, RecentRaces: []F1RaceResultV2{
+				race(1, 3, 3, 15, true), race(2, 5, 5, 10, true), race(3, 4, 4, 12, true),
+				race(4, 2, 2, 18, true), race(5, 6, 0, 0, false), race(6, 4, 5, 5, true),
+			}}},
+		},
+		RecRaw:          12,
+		Rows:            5,
+		PPR3yRaw:        8,
+		ChampPctRaw:     0.5,
+		TeamStrengthRaw: 0.2,
+		MomentumRaw:     480, // projected 120 × 24 ÷ 6 = 480: flat trend
+	}
+}
+
+func TestProjectPointsRate(t *testing.T) {
+	model := NewF1QuantumPricingModelV2()
+	leader := projectionDriver()
+	leader.BasicData.Name = "Leader"
+	leader.BasicData.Seasons[0].Points = 120 // 20 per race
+	d := projectionDriver()
+	proj := model.ProjectPoints([]*F1CompleteDriverV2{d, leader})[0]
+
+	cS := 6.0 / 12
+	base := cS*0.5*20 + (1-cS)*8
+	cL := 5.0 / 8
+	driver := cL*12 + (1-cL)*base
+	car := 0.2 * 101 / 2
+	want := 0.8*driver + 0.2*car
+	if !approx(proj.Rate, want) || proj.Next != proj.Rate {
+		t.Fatalf("rate = %v, want %v", proj.Rate, want)
+	}
+	if proj.Remaining != 18 || !approx(proj.ROS, 18*want) {
+		t.Errorf("ROS = %v over %d races, want %v over 18", proj.ROS, proj.Remaining, 18*want)
+	}
+	if !(proj.NextLow < proj.Next && proj.Next < proj.NextHigh) || !(proj.ROSLow < proj.ROS && proj.ROS < proj.ROSHigh) {
+		t.Errorf("bands do not contain the mean: %+v", proj)
+	}
+	// the rest-of-season band is wider than 18 independent races alone
+	z := 1.2815515655446004
+	if proj.ROSHigh-proj.ROS <= z*math.Sqrt(18)*proj.RaceSD {
+		t.Errorf("ROS band ignores rate uncertainty: %+v", proj)
+	}
+}
+
+func TestProjectPointsTrendAndValue(t *testing.T) {
+	model := NewF1QuantumPricingModelV2()
+	flat := model.ProjectPoints([]*F1CompleteDriverV2{projectionDriver()})[0]
+
+	up := projectionDriver()
+	up.MomentumRaw = 300 // team scoring well above its weighted history: clamped at +25 %
+	rising := model.ProjectPoints([]*F1CompleteDriverV2{up})[0]
+	if !approx(rising.Rate, flat.Rate*(1+0.5*0.25)) {
+		t.Errorf("rising team rate = %v, want %v", rising.Rate, flat.Rate*1.125)
+	}
+
+	v := flat.withPrice(20)
+	if !approx(v.NextPerM, flat.Next/20) || !approx(v.ROSPerM, flat.ROS/20) {
+		t.Errorf("value per million = %v / %v", v.NextPerM, v.ROSPerM)
+	}
+	if z := flat.withPrice(0); z.NextPerM != 0 {
+		t.Errorf("unpriced value per million = %v, want 0", z.NextPerM)
+	}
+
+	// calendar falls back to the driver's own fields
+	d := projectionDriver()
+	d.BasicData.TeamData.TotalRaces, d.BasicData.TotalRacesInSeason = 0, 22
+	if p := model.ProjectPoints([]*F1CompleteDriverV2{d})[0]; p.Remaining != 16 {
+		t.Errorf("remaining = %d, want 16", p.Remaining)
+	}
+}
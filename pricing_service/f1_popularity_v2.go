@@ -0,0 +1,167 @@
+package pricingservice
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// ============================================================
+//  POPULARITY, AGE CURVE & TEAM LEADER  (POP / AGE / LEAD terms)
+// ============================================================
+//
+//	POP  = (1 − CareerWeight)·level + CareerWeight·min(starts ÷ 150, 1)
+//	       level: MarketPopularity High 0.9 / Medium 0.6 / Low 0.3
+//	       with follower data: (1 − FollowersWeight)·POP + FollowersWeight·F,
+//	       F = log10(1 + followers) min-max scaled over the drivers with data
+//	AGE  = model.AgeCurve.Delta(age, starts)  expected development (age_curve.go)
+//	LEAD = 1 designated #1, 0 their teammate, 0.5 when the team names nobody
+//
+// Each is Z-scored across the grid (±3). None of them moves with a race
+// result, so the live weekend has no stage for them.
+
+const (
+	defaultPopCareerWeight    = 0.25
+	defaultPopFollowersWeight = 0.50
+	popCareerStarts           = 150.0 // starts for full career longevity
+)
+
+// F1PopularityConfigV2 shapes the POP metric.
+type F1PopularityConfigV2 struct {
+	CareerWeight    float64 // share of career longevity in the base popularity
+	FollowersWeight float64 // share of the follower signal when a driver has one
+}
+
+func NewF1PopularityConfigV2() F1PopularityConfigV2 {
+	return F1PopularityConfigV2{
+		CareerWeight:    defaultPopCareerWeight,
+		FollowersWeight: defaultPopFollowersWeight,
+	}
+}
+
+// LoadFollowersCSV reads a followers CSV with a header of driver, followers
+// and an optional platform column; a driver's rows are summed over
+// platforms. Keys are lower-case driver names.
+func LoadFollowersCSV(path string) (map[string]float64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("error opening followers CSV: %v", err)
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.FieldsPerRecord = -1
+	header, err := r.Read()
+	if err != nil {
+		return nil, fmt.Errorf("error reading followers CSV header: %v", err)
+	}
+	col := map[string]int{}
+	for i, h := range header {
+		col[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+	for _, name := range []string{"driver", "followers"} {
+		if _, ok := col[name]; !ok {
+			return nil, fmt.Errorf("followers CSV: missing column %q", name)
+		}
+	}
+
+	out := map[string]float64{}
+	for line := 2; ; line++ {
+		rec, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error reading followers CSV line %d: %v", line, err)
+		}
+		if len(rec) <= max(col["driver"], col["followers"]) {
+			return nil, fmt.Errorf("followers CSV line %d: too few fields", line)
+		}
+		name := strings.ToLower(strings.TrimSpace(rec[col["driver"]]))
+		if name == "" {
+			return nil, fmt.Errorf("followers CSV line %d: driver is required", line)
+		}
+		n, err := strconv.ParseFloat(strings.TrimSpace(rec[col["followers"]]), 64)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("followers CSV line %d: invalid followers %q", line, rec[col["followers"]])
+		}
+		out[name] += n
+	}
+	return out, nil
+}
+
+func popularityLevel(p F1PopularityLevelV2) float64 {
+	switch p {
+	case F1HighPopularityV2:
+		return 0.9
+	case F1MediumPopularityV2:
+		return 0.6
+	case F1LowPopularityV2:
+		return 0.3
+	}
+	return 0.5
+}
+
+// followerScores maps each driver with follower data to F (0-1).
+func followerScores(drvs []*F1CompleteDriverV2, followers map[string]float64) map[*F1CompleteDriverV2]float64 {
+	logs := map[*F1CompleteDriverV2]float64{}
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, d := range drvs {
+		n, ok := followers[strings.ToLower(d.BasicData.Name)]
+		if !ok {
+			continue
+		}
+		l := math.Log10(1 + n)
+		logs[d] = l
+		lo, hi = math.Min(lo, l), math.Max(hi, l)
+	}
+	for d, l := range logs {
+		if hi > lo {
+			logs[d] = (l - lo) / (hi - lo)
+		} else {
+			logs[d] = 0.5
+		}
+	}
+	return logs
+}
+
+// computePopularity fills PopRaw/AgeRaw/LeadRaw and their Zs.
+func (model *F1QuantumPricingModelV2) computePopularity(drvs []*F1CompleteDriverV2) {
+	cfg := model.Popularity
+	social := followerScores(drvs, model.Followers)
+
+	leaders := map[string]bool{}
+	for _, d := range drvs {
+		if d.BasicData.IsTeamLeader {
+			leaders[d.TeamID] = true
+		}
+	}
+
+	for _, d := range drvs {
+		b := &d.BasicData
+		career := math.Min(float64(b.CareerStarts)/popCareerStarts, 1)
+		d.PopRaw = (1-cfg.CareerWeight)*popularityLevel(b.MarketPopularity) + cfg.CareerWeight*career
+		if f, ok := social[d]; ok {
+			d.PopRaw = (1-cfg.FollowersWeight)*d.PopRaw + cfg.FollowersWeight*f
+		}
+
+		d.AgeRaw = model.AgeCurve.Delta(b.Age, b.CareerStarts) // 0 when age unknown
+
+		switch {
+		case b.IsTeamLeader:
+			d.LeadRaw = 1
+		case leaders[d.TeamID]:
+			d.LeadRaw = 0
+		default:
+			d.LeadRaw = 0.5
+		}
+	}
+
+	zBatch(drvs, func(d *F1CompleteDriverV2) float64 { return d.PopRaw }, func(d *F1CompleteDriverV2, z float64) { d.PopZ = z })
+	zBatch(drvs, func(d *F1CompleteDriverV2) float64 { return d.AgeRaw }, func(d *F1CompleteDriverV2, z float64) { d.AgeZ = z })
+	zBatch(drvs, func(d *F1CompleteDriverV2) float64 { return d.LeadRaw }, func(d *F1CompleteDriverV2, z float64) { d.LeadZ = z })
+}
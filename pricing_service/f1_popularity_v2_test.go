@@ -0,0 +1,77 @@
+package pricingservice
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestComputePopularity(t *testing.T) {
+	model := NewF1QuantumPricingModelV2()
+	model.Followers = map[string]float64{"a": 9_999_999, "b": 99_999}
+	mk := func(name, team string, pop F1PopularityLevelV2, age, starts int, leader bool) *F1CompleteDriverV2 {
+		return &F1CompleteDriverV2{TeamID: team, BasicData: F1BasicDriverDataV2{
+			Name: name, Age: age, CareerStarts: starts, MarketPopularity: pop, IsTeamLeader: leader,
+			TeamData: F1TeamDataV2{Name: team},
+		}}
+	}
+	a := mk("A", "Red", F1HighPopularityV2, 28, 300, true)
+	b := mk("B", "Red", F1LowPopularityV2, 22, 30, false)
+	b.BasicData.TeamData.Name = "Red Racing" // another spelling of A's team
+	c := mk("C", "Blue", F1MediumPopularityV2, 40, 0, false)
+	d := mk("D", "Blue", "", 0, 75, false)
+	model.computePopularity([]*F1CompleteDriverV2{a, b, c, d})
+
+	// A: 0.75·0.9 + 0.25·1 = 0.925, followers at the top of the range
+	if !approx(a.PopRaw, 0.5*0.925+0.5*1) {
+		t.Errorf("A PopRaw = %v", a.PopRaw)
+	}
+	if !approx(b.PopRaw, 0.5*(0.75*0.3+0.25*0.2)) {
+		t.Errorf("B PopRaw = %v (bottom of the follower range)", b.PopRaw)
+	}
+	if !approx(d.PopRaw, 0.75*0.5+0.25*0.5) {
+		t.Errorf("D PopRaw = %v, want no follower blend", d.PopRaw)
+	}
+
+	curve := NewAgeCurve()
+	if !approx(a.AgeRaw, curve.Delta(28, 300)) || !approx(c.AgeRaw, curve.Delta(40, 0)) || d.AgeRaw != 0 {
+		t.Errorf("AgeRaw = %v %v %v, want the age curve's Δ", a.AgeRaw, c.AgeRaw, d.AgeRaw)
+	}
+	if a.LeadRaw != 1 || b.LeadRaw != 0 || c.LeadRaw != 0.5 || d.LeadRaw != 0.5 {
+		t.Errorf("LeadRaw = %v %v %v %v, want 1 0 .5 .5", a.LeadRaw, b.LeadRaw, c.LeadRaw, d.LeadRaw)
+	}
+	if !(a.PopZ > 0 && b.PopZ < 0 && b.AgeZ > c.AgeZ && a.LeadZ > c.LeadZ) {
+		t.Errorf("Zs out of order: pop %v/%v age %v/%v lead %v/%v", a.PopZ, b.PopZ, b.AgeZ, c.AgeZ, a.LeadZ, c.LeadZ)
+	}
+
+	// the terms reach rawScore
+	w := NewF1ScoreWeightsV2()
+	base := w.rawScore(a)
+	w.POP = 0
+	if !approx(base-w.rawScore(a), wPOP*a.PopZ) || math.Abs(a.PopZ) < eps {
+		t.Errorf("POP term not in rawScore")
+	}
+}
+
+func TestLoadFollowersCSV(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "followers.csv")
+	body := "Driver,Platform,Followers\nMax Verstappen,instagram,16000000\nmax verstappen,x,4000000\nOscar Piastri,instagram,2500000\n"
+	if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	got, err := LoadFollowersCSV(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got["max verstappen"] != 20_000_000 || got["oscar piastri"] != 2_500_000 {
+		t.Errorf("followers = %v", got)
+	}
+
+	if err := os.WriteFile(path, []byte("driver,followers\nA,lots\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadFollowersCSV(path); err == nil {
+		t.Errorf("non-numeric followers accepted")
+	}
+}
@@ -0,0 +1,132 @@
+package pricingservice
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// ============================================================
+//  PRICE CURVES  (Strength → position inside the budget band)
+// ============================================================
+//
+// base = pMin + (pMax − pMin) · curve(ScaledStrength)
+//
+// Every curve is monotone non-decreasing on [0,1] with curve(0)=0 and
+// curve(1)=1, so solveBand can still walk the band to the spend target
+// whatever family is selected.
+
+// F1PriceCurveV2 maps a driver's ScaledStrength to a position in the band.
+type F1PriceCurveV2 interface {
+	Name() string
+	// Prepare is called once per pricing run with every driver's ScaledStrength.
+	Prepare(scaled []float64)
+	// Position maps ScaledStrength (0-1) to a position in the band (0-1).
+	Position(s float64) float64
+}
+
+// F1LinearCurveV2 spaces prices evenly with strength (the original mapping).
+type F1LinearCurveV2 struct{}
+
+func (F1LinearCurveV2) Name() string               { return "linear" }
+func (F1LinearCurveV2) Prepare([]float64)          {}
+func (F1LinearCurveV2) Position(s float64) float64 { return clamp(s, 0, 1) }
+
+// F1PowerCurveV2 is s^Exponent: > 1 is convex (premium drivers pull away),
+// < 1 is concave (midfield bunched toward the top).
+type F1PowerCurveV2 struct{ Exponent float64 }
+
+func (c F1PowerCurveV2) Name() string    { return fmt.Sprintf("power(%.2f)", c.Exponent) }
+func (F1PowerCurveV2) Prepare([]float64) {}
+func (c F1PowerCurveV2) Position(s float64) float64 {
+	return math.Pow(clamp(s, 0, 1), c.Exponent)
+}
+
+// F1TierCurveV2 is a step function: strengths at or above Breaks[i] land on
+// Levels[i+1]; below Breaks[0] on Levels[0]. len(Levels) = len(Breaks)+1;
+// build it with NewF1TierCurveV2, which checks that.
+type F1TierCurveV2 struct {
+	Breaks []float64 // ascending ScaledStrength thresholds
+	Levels []float64 // ascending band positions, first 0 and last 1
+}
+
+// NewF1TierCurveV2 checks the tiers: one more level than breaks, breaks
+// ascending inside (0,1], levels non-decreasing from 0 to 1.
+func NewF1TierCurveV2(breaks, levels []float64) (F1TierCurveV2, error) {
+	if len(levels) != len(breaks)+1 {
+		return F1TierCurveV2{}, fmt.Errorf("tier curve: %d levels for %d breaks, want %d", len(levels), len(breaks), len(breaks)+1)
+	}
+	for i, b := range breaks {
+		if b <= 0 || b > 1 || (i > 0 && b <= breaks[i-1]) {
+			return F1TierCurveV2{}, fmt.Errorf("tier curve: break %v is not ascending inside (0, 1]", b)
+		}
+	}
+	if levels[0] != 0 || levels[len(levels)-1] != 1 {
+		return F1TierCurveV2{}, fmt.Errorf("tier curve: levels run %v to %v, want 0 to 1", levels[0], levels[len(levels)-1])
+	}
+	for i := 1; i < len(levels); i++ {
+		if levels[i] < levels[i-1] {
+			return F1TierCurveV2{}, fmt.Errorf("tier curve: level %v falls below %v", levels[i], levels[i-1])
+		}
+	}
+	return F1TierCurveV2{Breaks: breaks, Levels: levels}, nil
+}
+
+func (c F1TierCurveV2) Name() string    { return fmt.Sprintf("tiers(%d)", len(c.Levels)) }
+func (F1TierCurveV2) Prepare([]float64) {}
+func (c F1TierCurveV2) Position(s float64) float64 {
+	tier := sort.Search(len(c.Breaks), func(i int) bool { return c.Breaks[i] > s })
+	return c.Levels[tier]
+}
+
+// F1QuantileCurveV2 places each driver by their rank on the grid, so price
+// gaps follow the order of drivers rather than the gaps in their strengths.
+type F1QuantileCurveV2 struct {
+	sorted      []float64
+	first, last float64 // ranks of the weakest and the strongest group
+}
+
+func (*F1QuantileCurveV2) Name() string { return "quantile" }
+func (c *F1QuantileCurveV2) Prepare(scaled []float64) {
+	c.sorted = append(c.sorted[:0], scaled...)
+	sort.Float64s(c.sorted)
+	if n := len(c.sorted); n > 0 {
+		c.first, c.last = c.rank(c.sorted[0]), c.rank(c.sorted[n-1])
+	}
+}
+
+// rank is the mid-rank of s among the prepared strengths (tied drivers
+// share it), half a place below its neighbour when s is not on the grid.
+func (c *F1QuantileCurveV2) rank(s float64) float64 {
+	lo := sort.SearchFloat64s(c.sorted, s)
+	hi := sort.Search(len(c.sorted), func(i int) bool { return c.sorted[i] > s })
+	if lo == hi {
+		return float64(lo) - 0.5
+	}
+	return float64(lo+hi-1) / 2
+}
+
+func (c *F1QuantileCurveV2) Position(s float64) float64 {
+	if len(c.sorted) < 2 || c.last <= c.first {
+		return clamp(s, 0, 1)
+	}
+	// rescale so the weakest group is 0 and the strongest 1, ties included
+	return clamp((c.rank(s)-c.first)/(c.last-c.first), 0, 1)
+}
+
+// NewF1PriceCurveV2 builds a curve from its configuration name:
+// "linear", "power", "tiers" or "quantile".
+func NewF1PriceCurveV2(name string) (F1PriceCurveV2, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "linear":
+		return F1LinearCurveV2{}, nil
+	case "power", "convex":
+		return F1PowerCurveV2{Exponent: 1.6}, nil
+	case "tiers", "piecewise":
+		return NewF1TierCurveV2([]float64{0.25, 0.50, 0.75}, []float64{0, 0.30, 0.60, 1})
+	case "quantile":
+		return &F1QuantileCurveV2{}, nil
+	}
+	return nil, fmt.Errorf("unknown price curve %q", name)
+}
@@ -0,0 +1,101 @@
+package pricingservice
+
+import (
+	"math"
+	"testing"
+)
+
+// curveGrid is a scaled-strength grid with ties at the bottom, middle and top.
+var curveGrid = []float64{0, 0, 0.1, 0.24, 0.25, 0.4, 0.5, 0.5, 0.5, 0.62, 0.75, 0.8, 0.93, 1, 1}
+
+func TestPriceCurvesMonotone(t *testing.T) {
+	for _, name := range []string{"linear", "power", "tiers", "quantile"} {
+		c, err := NewF1PriceCurveV2(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c.Prepare(curveGrid)
+		if got := c.Position(0); got != 0 {
+			t.Errorf("%s: curve(0) = %v, want 0", name, got)
+		}
+		if got := c.Position(1); got != 1 {
+			t.Errorf("%s: curve(1) = %v, want 1", name, got)
+		}
+		prev := math.Inf(-1)
+		for i := 0; i <= 200; i++ {
+			s := float64(i) / 200
+			got := c.Position(s)
+			if got < prev-eps || got < 0 || got > 1 {
+				t.Errorf("%s: curve(%v) = %v after %v", name, s, got, prev)
+			}
+			prev = got
+		}
+	}
+}
+
+func TestQuantileCurveTies(t *testing.T) {
+	c := &F1QuantileCurveV2{}
+	c.Prepare([]float64{1, 0.2, 0.2, 0.9, 1, 0})
+	tests := []struct{ s, want float64 }{
+		{0, 0},
+		{0.2, 1.5 / 4.5},  // mid-rank 1.5; the tied top group sits at 4.5
+		{0.5, 2.5 / 4.5},  // between the groups, half a place below 0.9
+		{0.9, 3 / 4.5},    // rank 3
+		{1, 1},            // tied strongest group
+		{0.95, 3.5 / 4.5}, // half a place below the top group
+	}
+	for _, tt := range tests {
+		if got := c.Position(tt.s); !approx(got, tt.want) {
+			t.Errorf("Position(%v) = %v, want %v", tt.s, got, tt.want)
+		}
+	}
+
+	// a grid of equal strengths has no ranks to spread
+	c.Prepare([]float64{0.5, 0.5})
+	if got := c.Position(0.5); got != 0.5 {
+		t.Errorf("all tied: Position = %v, want the strength itself", got)
+	}
+}
+
+func TestSolveBandWithEachCurve(t *testing.T) {
+	for _, name := range []string{"linear", "power", "tiers", "quantile"} {
+		model := NewF1QuantumPricingModelV2()
+		curve, err := NewF1PriceCurveV2(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		model.PriceCurve = curve
+		drvs := bandDrivers(20)
+		scaled := make([]float64, len(drvs))
+		for i, d := range drvs {
+			scaled[i] = d.ScaledStrength
+		}
+		curve.Prepare(scaled)
+
+		sol := model.solveBand(drvs, nil, 50, 2)
+		if sol.Binding != "none" || math.Abs(sol.Slack) > model.Band.Tolerance {
+			t.Errorf("%s: binding %q, slack %v; want the target within %v", name, sol.Binding, sol.Slack, model.Band.Tolerance)
+		}
+	}
+}
+
+func TestNewF1TierCurveV2(t *testing.T) {
+	if _, err := NewF1TierCurveV2([]float64{0.5}, []float64{0, 1}); err != nil {
+		t.Errorf("valid tiers rejected: %v", err)
+	}
+	for _, tt := range []struct {
+		name           string
+		breaks, levels []float64
+	}{
+		{"missing level", []float64{0.25, 0.5}, []float64{0, 1}},
+		{"extra level", []float64{0.5}, []float64{0, 0.5, 0.8, 1}},
+		{"breaks out of order", []float64{0.5, 0.25}, []float64{0, 0.5, 1}},
+		{"break outside the band", []float64{1.2}, []float64{0, 1}},
+		{"levels short of 1", []float64{0.5}, []float64{0, 0.8}},
+		{"levels falling", []float64{0.3, 0.6, 0.8}, []float64{0, 0.7, 0.4, 1}},
+	} {
+		if _, err := NewF1TierCurveV2(tt.breaks, tt.levels); err == nil {
+			t.Errorf("%s: accepted breaks %v, levels %v", tt.name, tt.breaks, tt.levels)
+		}
+	}
+}
@@ -118,17 +118,32 @@ type F1BasicDriverData struct {
 
 	PreviousTeam         string
 	RacesWithCurrentTeam int
+
+	CurrentPrice float64 // last published price (0 = never priced)
 }
 
 type F1RaceResult struct {
 	RaceName       string
 	RaceNumber     int // Race number in season (1-24)
 	FinishPosition int
-	StartPosition  int // Grid position
+	StartPosition  int // Grid position the race started from, after penalties
 	PointsScored   float64
 	FastestLap     bool
 	DNF            bool
 	Classified     bool // Completed >90% race distance
+
+	QualifyingPosition int // Where the driver qualified (0 = unknown, StartPosition is used)
+	GridPenaltyPlaces  int // Places StartPosition was dropped by a grid penalty
+}
+
+// paceGrid is the grid slot the driver earned on pace (see expected_position.go)
+func (r F1RaceResult) paceGrid() int {
+	return paceGrid(r.QualifyingPosition, r.StartPosition, r.GridPenaltyPlaces)
+}
+
+// RaceDrift = mean recent finish − mean recent qualifying position
+func (t F1TeamData) RaceDrift() float64 {
+	return raceDrift(t.RecentRacePositions, t.RecentQualifyingPositions)
 }
 
 // BasicSeasonStats holds the minimum publicly available data for a season
@@ -151,6 +166,7 @@ type F1BasicSeasonStats struct {
 type F1CompleteDriver struct {
 	// User-provided data
 	BasicData F1BasicDriverData
+	TeamID    string // registry ID of BasicData.Team
 
 	// Maps for specialties/weaknesses
 	SpecialtiesMap map[string]bool
@@ -165,7 +181,7 @@ type F1CompleteDriver struct {
 	MerchandiseSales       float64
 	HomeMarketSize         float64
 	FanbaseSize            float64
-	ThreeYearTrend         float64
+	AgeTrend               float64 // expected development, −1…+1 (age_curve.go)
 	TeamStrength           float64
 	NormalizedTeamStrength float64
 
@@ -193,6 +209,7 @@ type F1DriverPrice struct {
 	Driver             F1CompleteDriver
 	Price              float64
 	ComponentBreakdown map[string]float64
+	RuleLog            []PriceRuleLogEntry
 }
 
 //
@@ -203,6 +220,44 @@ type F1QuantumPricingModel struct {
 	TotalNumberOfRaces     int
 	CurrentRace            int
 	TotalPointsInTheSeason int
+
+	// Business rules applied after psychological pricing (nil = none).
+	// The sheet is published for round CurrentRace + 1.
+	Rules *PriceRuleSet
+
+	// Price presentation (psychological pricing strategy)
+	Rounding PriceRounding
+
+	// Base price and premium multipliers of calculateDriverPrice
+	Premiums F1PriceMultipliers
+
+	// Expected development by age and experience, behind AgeTrend
+	AgeCurve AgeCurve
+
+	// Team identities, so a rebrand or respelling is not a team change
+	Teams *TeamRegistry
+}
+
+// F1PriceMultipliers scales each price component (millions per unit).
+type F1PriceMultipliers struct {
+	Base             float64 // price of an average-strength team's driver
+	TeamStrength     float64 // per σ of NormalizedTeamStrength
+	Teammate         float64 // × OverperformanceFactor (floored at 2)
+	Performance      float64 // × PerformanceRatio
+	Consistency      float64 // × Consistency
+	ChampionPerTitle float64
+	ChampionCap      float64
+	Trend            float64 // × AgeTrend
+	Popularity       float64 // × (MarketPopularity − 0.5)
+	Upgrade          float64 // teams with recent upgrades
+	Ability          float64 // × (mean ability − 0.5)
+}
+
+func NewF1PriceMultipliers() F1PriceMultipliers {
+	return F1PriceMultipliers{
+		Base: 15.0, TeamStrength: 5.0, Teammate: 2, Performance: 3.0, Consistency: 3.0,
+		ChampionPerTitle: 0.5, ChampionCap: 3, Trend: 3.0, Popularity: 2.0, Upgrade: 1, Ability: 5.0,
+	}
 }
 
 func NewF1QuantumPricingModel(totalNumberOfRaces int, currentRace int, totalPointsInTheSeason int) *F1QuantumPricingModel {
@@ -210,14 +265,20 @@ func NewF1QuantumPricingModel(totalNumberOfRaces int, currentRace int, totalPoin
 		TotalNumberOfRaces:     totalNumberOfRaces,
 		CurrentRace:            currentRace,
 		TotalPointsInTheSeason: totalPointsInTheSeason,
+		Rounding:               DefaultPriceRounding("f1", "v1"),
+		Premiums:               NewF1PriceMultipliers(),
+		AgeCurve:               NewAgeCurve(),
+		Teams:                  NewTeamRegistry(),
 	}
 }
 
 // NewCompleteDriver creates a complete driver from basic data and calculates all attributes
 func (m *F1QuantumPricingModel) NewCompleteDriver(basicData F1BasicDriverData) F1CompleteDriver {
 	// Create complete driver with empty maps
+	teamID, _ := m.Teams.Resolve(basicData.Team) // checked by checkTeams
 	driver := F1CompleteDriver{
 		BasicData:      basicData,
+		TeamID:         teamID,
 		SpecialtiesMap: make(map[string]bool),
 		WeaknessesMap:  make(map[string]bool),
 		Abilities:      make(map[string]float64),
@@ -252,8 +313,8 @@ func (m *F1QuantumPricingModel) calculateAllDerivedMetrics(driver *F1CompleteDri
 	// Calculate popularity metrics
 	m.calculatePopularityMetrics(driver)
 
-	// Calculate three-year trend
-	driver.ThreeYearTrend = m.calculateThreeYearTrend(driver)
+	// Calculate age-curve trend
+	driver.AgeTrend = m.calculateAgeTrend(driver)
 
 	// Calculate overperformance factor
 	driver.OverperformanceFactor = m.calculateOverperformanceFactor(driver)
@@ -267,7 +328,8 @@ func (m *F1QuantumPricingModel) calculateAllDerivedMetrics(driver *F1CompleteDri
 	driver.MarketPopularity = m.calculateMarketPopularity(driver)
 }
 
-// calculateCareerTeamChanges counts how many different teams a driver has raced for
+// calculateCareerTeamChanges counts how many different teams a driver has raced for.
+// Names resolve through the team registry (checkTeams has rejected unknown ones).
 func (m *F1QuantumPricingModel) calculateCareerTeamChanges(driver *F1CompleteDriver) int {
 	// Early exit if no seasons data
 	if len(driver.BasicData.Seasons) == 0 {
@@ -278,7 +340,8 @@ func (m *F1QuantumPricingModel) calculateCareerTeamChanges(driver *F1CompleteDri
 	uniqueTeams := make(map[string]bool)
 	for _, season := range driver.BasicData.Seasons {
 		if season.Team != "" {
-			uniqueTeams[season.Team] = true
+			id, _ := m.Teams.Resolve(season.Team)
+			uniqueTeams[id] = true
 		}
 	}
 
@@ -557,70 +620,10 @@ func (m *F1QuantumPricingModel) calculateSeasonPhase(driver *F1CompleteDriver) F
 	}
 }
 
-// calculateThreeYearTrend computes a driver's performance trajectory
-func (m *F1QuantumPricingModel) calculateThreeYearTrend(driver *F1CompleteDriver) float64 {
-	// Early exit if rookie
-	if driver.BasicData.IsRookie {
-		return 0
-	}
-
-	// Check for hiatus in career
-	hasHiatus := false
-	if len(driver.BasicData.Seasons) >= 2 {
-		yearGap := driver.BasicData.Seasons[0].Year - driver.BasicData.Seasons[1].Year
-		if yearGap > 1 {
-			hasHiatus = true
-		}
-	}
-
-	// Early exit if insufficient data
-	if len(driver.BasicData.Seasons) < 2 {
-		return 0.0 // Neutral value
-	}
-
-	// Get up to 4 seasons of data
-	// Calculate normalized points for each season
-	normalizedPoints := make([]float64, len(driver.BasicData.Seasons))
-
-	// For the previous seasons, we need to estimate team strength using historical data
-	for i, season := range driver.BasicData.Seasons {
-		// Normalize points by team strength
-		normalizedPoints[i] = season.Points / float64(season.Races)
-	}
-
-	n := float64(len(normalizedPoints))
-	sumX := 0.0
-	sumY := 0.0
-	sumXY := 0.0
-	sumX2 := 0.0
-
-	for i, point := range normalizedPoints {
-		x := float64(i)
-		y := point
-		sumX += x
-		sumY += y
-		sumXY += x * y
-		sumX2 += x * x
-	}
-
-	// Calculate slope using least squares method
-	slope := (n*sumXY - sumX*sumY) / (n*sumX2 - sumX*sumX)
-
-	// Special handling for returning drivers after hiatus
-	if !hasHiatus && len(normalizedPoints) >= 2 {
-		// Scale to our -1.0 to 1.0 range
-		return math.Max(-1.0, math.Min(1.0, slope))
-	}
-
-	if slope > 0 {
-		return math.Max(-1.0, math.Min(1.0, slope))
-	}
-
-	if slope < -1 {
-		return -0.4
-	}
-
-	return math.Max(-1.0, math.Min(1.0, slope+0.2))
+// calculateAgeTrend is the driver's expected development over the next
+// season from the career age curve (see age_curve.go), on −1…+1
+func (m *F1QuantumPricingModel) calculateAgeTrend(driver *F1CompleteDriver) float64 {
+	return m.AgeCurve.Trend(driver.BasicData.Age, driver.BasicData.CareerStarts)
 }
 
 // calculateOverperformanceFactor estimates how much a driver exceeds car potential
@@ -677,8 +680,8 @@ func (m *F1QuantumPricingModel) calculateOverperformanceFactor(driver *F1Complet
 				return driver.BasicData.Seasons[i].Year > driver.BasicData.Seasons[j].Year
 			})
 			for _, race := range driver.BasicData.Seasons[0].RecentRaces {
-				if race.StartPosition > 0 {
-					driverQualifying += float64(race.StartPosition)
+				if grid := race.paceGrid(); grid > 0 {
+					driverQualifying += float64(grid)
 					qualifyingCount++
 				}
 			}
@@ -885,6 +888,12 @@ func (m *F1QuantumPricingModel) deriveDriverAbilities(driver *F1CompleteDriver)
 		}
 	}
 
+	// --- RACECRAFT FROM RESULTS ---
+	// places made against the pace-adjusted expected finish, +3 avg = +0.10
+	if avgGain, raceCount := paceAdjustedGain(driver); raceCount >= 3 {
+		abilities["OvertakingSkill"] += 0.10 * math.Max(-1.0, math.Min(1.0, avgGain/3.0))
+	}
+
 	// --- ENSURE ALL VALUES STAY WITHIN 0.0-1.0 RANGE ---
 	for key := range abilities {
 		abilities[key] = math.Max(0.0, math.Min(abilities[key], 1.0))
@@ -910,18 +919,8 @@ func (m *F1QuantumPricingModel) calculatePerformanceRatio(driver *F1CompleteDriv
 		return driver.BasicData.Seasons[i].Year > driver.BasicData.Seasons[j].Year
 	})
 
-	var totalGain, raceCount float64
-	for _, race := range driver.BasicData.Seasons[0].RecentRaces {
-		if race.StartPosition > 0 && race.FinishPosition > 0 && race.FinishPosition <= 20 {
-			gain := float64(race.StartPosition - race.FinishPosition) // Positive = gained positions
-			totalGain += gain
-			raceCount++
-		}
-	}
-
 	var gainScore float64
-	if raceCount > 0 {
-		avgGain := totalGain / raceCount
+	if avgGain, raceCount := paceAdjustedGain(driver); raceCount > 0 {
 		// Normalize to [-1, 1]: +3 avg = +1, 0 avg = 0, -3 avg = -1
 		gainScore = math.Max(-1.0, math.Min(1.0, avgGain/4.0))
 	}
@@ -994,6 +993,32 @@ func (m *F1QuantumPricingModel) calculatePerformanceRatio(driver *F1CompleteDriv
 	return performanceRatio
 }
 
+// paceAdjustedGain averages places gained against the pace-adjusted expected
+// finish over the newest season's races that have a grid and a finish.
+func paceAdjustedGain(driver *F1CompleteDriver) (avgGain float64, raceCount int) {
+	newest := -1
+	for i, s := range driver.BasicData.Seasons {
+		if newest < 0 || s.Year > driver.BasicData.Seasons[newest].Year {
+			newest = i
+		}
+	}
+	if newest < 0 {
+		return 0, 0
+	}
+	drift := driver.BasicData.TeamData.RaceDrift()
+	var totalGain float64
+	for _, race := range driver.BasicData.Seasons[newest].RecentRaces {
+		if race.paceGrid() > 0 && race.FinishPosition > 0 && race.FinishPosition <= 20 {
+			totalGain += paceGain(race.paceGrid(), race.FinishPosition, drift) // Positive = gained positions
+			raceCount++
+		}
+	}
+	if raceCount == 0 {
+		return 0, 0
+	}
+	return totalGain / float64(raceCount), raceCount
+}
+
 // calculateConsistency measures how reliable a driver's performance is
 // Range: 0.65-0.95
 func (m *F1QuantumPricingModel) calculateConsistency(driver *F1CompleteDriver) float64 {
@@ -1009,8 +1034,8 @@ func (m *F1QuantumPricingModel) calculateConsistency(driver *F1CompleteDriver) f
 	// COMPONENT 1: Qualifying Position Consistency (35%)
 	var qualifyingPositions []float64
 	for _, race := range driver.BasicData.Seasons[0].RecentRaces {
-		if race.StartPosition > 0 {
-			qualifyingPositions = append(qualifyingPositions, float64(race.StartPosition))
+		if grid := race.paceGrid(); grid > 0 {
+			qualifyingPositions = append(qualifyingPositions, float64(grid))
 		}
 	}
 
@@ -1096,41 +1121,39 @@ func (m *F1QuantumPricingModel) calculateMarketPopularity(driver *F1CompleteDriv
 // DRIVER PRICING SYSTEM
 //
 
-// calculateDriverPrice computes the final fantasy price for a driver
+// calculateDriverPrice computes the model price for a driver (before
+// business rules, see applyPriceRules)
 func (m *F1QuantumPricingModel) calculateDriverPrice(driver F1CompleteDriver) F1DriverPrice {
 	// Determine season phase
 	seasonPhase := m.calculateSeasonPhase(&driver)
 
-	// Base price from team strength
-	basePrice := math.Round(15.0 + (driver.NormalizedTeamStrength * 5.0))
-
-	fmt.Println(driver.BasicData.Name)
-	fmt.Println(driver.BasicData.TeamData.Name)
-	fmt.Println(basePrice)
+	pm := m.Premiums
 
 	// Base price from team strength
+	basePrice := math.Round(pm.Base + (driver.NormalizedTeamStrength * pm.TeamStrength))
+
 	// teammatePremium := math.Max(-2, math.Min(2, driver.OverperformanceFactor*3.0))
-	teammatePremium := math.Max(2, driver.OverperformanceFactor*2)
+	teammatePremium := math.Max(2, driver.OverperformanceFactor*pm.Teammate)
 
 	// Performance premium
-	performancePremium := driver.PerformanceRatio * 3.0
+	performancePremium := driver.PerformanceRatio * pm.Performance
 
 	// Consistency value
-	consistencyValue := (driver.Consistency) * 3.0
+	consistencyValue := (driver.Consistency) * pm.Consistency
 
 	// Championship premium
-	championPremium := math.Min(float64(driver.BasicData.ChampionshipWins)*0.5, 3)
+	championPremium := math.Min(float64(driver.BasicData.ChampionshipWins)*pm.ChampionPerTitle, pm.ChampionCap)
 
 	// Trend adjustment
-	trendAdjustment := driver.ThreeYearTrend * 3.0
+	trendAdjustment := driver.AgeTrend * pm.Trend
 
 	// Popularity premium - with season phase adjustment
-	popularityPremium := (driver.MarketPopularity - 0.5) * 2.0
+	popularityPremium := (driver.MarketPopularity - 0.5) * pm.Popularity
 
 	// Recent team upgrades adjustment
 	upgradeAdjustment := 0.0
 	if driver.BasicData.TeamData.RecentUpgrades {
-		upgradeAdjustment = 1 // Small bonus for teams that recently upgraded
+		upgradeAdjustment = pm.Upgrade // Small bonus for teams that recently upgraded
 	}
 
 	// Mid-season team change adjustment
@@ -1160,7 +1183,7 @@ func (m *F1QuantumPricingModel) calculateDriverPrice(driver F1CompleteDriver) F1
 		totalAbility += val
 	}
 	abilityScore := totalAbility / float64(len(driver.Abilities))
-	abilityPremium := (abilityScore - 0.5) * 5.0 // ±4M range
+	abilityPremium := (abilityScore - 0.5) * pm.Ability // ±4M range
 
 	// Calculate raw price with all adjustments
 	rawPrice := basePrice + teammatePremium + performancePremium + championPremium + trendAdjustment + popularityPremium +
@@ -1186,41 +1209,79 @@ func (m *F1QuantumPricingModel) calculateDriverPrice(driver F1CompleteDriver) F1
 	}
 
 	// Apply psychological price anchoring
-	finalPrice := m.applyPsychologicalPricing(rawPrice, driver.TeamStrength)
-	breakdown["Final Price"] = finalPrice
+	modelPrice := m.applyPsychologicalPricing(driver, rawPrice)
 
 	// Create and return DriverPrice object
 	driverPrice := F1DriverPrice{
 		Driver:             driver,
-		Price:              finalPrice,
+		Price:              modelPrice,
 		ComponentBreakdown: breakdown,
 	}
 
 	return driverPrice
 }
 
-// applyPsychologicalPricing applies strategic price anchoring
-func (m *F1QuantumPricingModel) applyPsychologicalPricing(price float64, teamStrength float64) float64 {
-	// Apply team-based price anchoring
-	if teamStrength > 1.3 { // Top teams
-		if price > 20 {
-			return math.Floor(price) + 0.9
-		} else {
-			return math.Floor(price) + 0.5
+// applyPriceRules applies business rules (caps, floors, freezes, overrides)
+// to the model price and records the final price.
+func (m *F1QuantumPricingModel) applyPriceRules(dp *F1DriverPrice) {
+	modelPrice := dp.Price
+	finalPrice, ruleLog := m.Rules.Apply(PriceRuleInput{
+		Driver:   dp.Driver.BasicData.Name,
+		Tier:     dp.Driver.BasicData.TeamData.BudgetTier,
+		Round:    m.CurrentRace + 1,
+		Previous: dp.Driver.BasicData.CurrentPrice,
+		Proposed: modelPrice,
+	})
+	dp.Price = finalPrice
+	dp.RuleLog = ruleLog
+	dp.ComponentBreakdown["Rule Adjustment"] = finalPrice - modelPrice
+	dp.ComponentBreakdown["Final Price"] = finalPrice
+}
+
+// applyPsychologicalPricing applies strategic price anchoring through the
+// configured rounding strategy (default "team-anchor", see price_rounding.go)
+func (m *F1QuantumPricingModel) applyPsychologicalPricing(driver F1CompleteDriver, price float64) float64 {
+	return m.Rounding.Round(price, PriceRoundingContext{
+		Driver:       driver.BasicData.Name,
+		Team:         driver.BasicData.Team,
+		Tier:         driver.BasicData.TeamData.BudgetTier,
+		TeamStrength: driver.TeamStrength,
+	})
+}
+
+// checkTeams verifies that every team name in the drivers' data resolves
+// through the team registry.
+func (m *F1QuantumPricingModel) checkTeams(basicDriversData []F1BasicDriverData) error {
+	for _, b := range basicDriversData {
+		if b.Team == "" {
+			return fmt.Errorf("driver %q: no current team", b.Name)
 		}
-	} else if teamStrength > 1.0 { // Midfield teams
-		if price > 15 {
-			return math.Floor(price) + 0.5
-		} else {
-			return math.Floor(price)
+		if _, err := m.Teams.Resolve(b.Team); err != nil {
+			return fmt.Errorf("driver %q: %v", b.Name, err)
+		}
+		if b.TeamData.Name != "" {
+			if _, err := m.Teams.Resolve(b.TeamData.Name); err != nil {
+				return fmt.Errorf("driver %q, team data: %v", b.Name, err)
+			}
+		}
+		for _, s := range b.Seasons {
+			if s.Team != "" {
+				if _, err := m.Teams.Resolve(s.Team); err != nil {
+					return fmt.Errorf("driver %q, %d season: %v", b.Name, s.Year, err)
+				}
+			}
 		}
-	} else { // Backmarker teams
-		return math.Floor(price*2) / 2 // Round to nearest 0.5
 	}
+	return nil
 }
 
 // ProcessAllDrivers calculates all attributes and prices for a set of drivers
-func (m *F1QuantumPricingModel) ProcessAllDrivers(basicDriversData []F1BasicDriverData) []F1DriverPrice {
+func (m *F1QuantumPricingModel) ProcessAllDrivers(basicDriversData []F1BasicDriverData) ([]F1DriverPrice, error) {
+	// every team name must resolve before teams are compared
+	if err := m.checkTeams(basicDriversData); err != nil {
+		return nil, err
+	}
+
 	// Convert basic drivers to complete drivers
 	completeDrivers := make([]F1CompleteDriver, len(basicDriversData))
 
@@ -1243,16 +1304,34 @@ func (m *F1QuantumPricingModel) ProcessAllDrivers(basicDriversData []F1BasicDriv
 		driverPrices[i] = m.calculateDriverPrice(driver)
 	}
 
-	return driverPrices
+	// Keep teammates on distinct prices if the presentation asks for it;
+	// the business rules come last so caps and floors still hold
+	m.Rounding.SeparateTeammates(len(driverPrices),
+		func(i int) string { return driverPrices[i].Driver.TeamID },
+		func(i int) float64 { return driverPrices[i].ComponentBreakdown["Raw Price"] },
+		nil,
+		func(i int) float64 { return driverPrices[i].Price },
+		func(i int, p float64) { driverPrices[i].Price = p })
+	for i := range driverPrices {
+		m.applyPriceRules(&driverPrices[i])
+	}
+
+	return driverPrices, nil
 }
 
 // ProcessSingleDriver calculates attributes and price for a single driver
-func (m *F1QuantumPricingModel) ProcessSingleDriver(basicDriverData F1BasicDriverData) F1DriverPrice {
+func (m *F1QuantumPricingModel) ProcessSingleDriver(basicDriverData F1BasicDriverData) (F1DriverPrice, error) {
+	if err := m.checkTeams([]F1BasicDriverData{basicDriverData}); err != nil {
+		return F1DriverPrice{}, err
+	}
+
 	// Convert basic driver to complete driver
 	completeDriver := m.NewCompleteDriver(basicDriverData)
 
 	// Calculate and return price
-	return m.calculateDriverPrice(completeDriver)
+	dp := m.calculateDriverPrice(completeDriver)
+	m.applyPriceRules(&dp)
+	return dp, nil
 }
 
 //
@@ -1264,13 +1343,13 @@ func (m *F1QuantumPricingModel) PrintDriverAttributesTable(driverPrices []F1Driv
 	fmt.Println("\n=== DRIVER ATTRIBUTES ===")
 	fmt.Println(strings.Repeat("-", 105))
 	fmt.Printf("%-20s %-12s %-12s %-12s %-12s %-12s\n",
-		"DRIVER", "TEAM", "PERFORMANCE", "CONSISTENCY", "POPULARITY", "TREND")
+		"DRIVER", "TEAM", "PERFORMANCE", "CONSISTENCY", "POPULARITY", "AGE TREND")
 	fmt.Println(strings.Repeat("-", 105))
 
 	// Sort drivers by team
 	sort.Slice(driverPrices, func(i, j int) bool {
-		if driverPrices[i].Driver.BasicData.Team != driverPrices[j].Driver.BasicData.Team {
-			return driverPrices[i].Driver.BasicData.Team < driverPrices[j].Driver.BasicData.Team
+		if ti, tj := driverPrices[i].Driver.TeamID, driverPrices[j].Driver.TeamID; ti != tj {
+			return ti < tj
 		}
 		return driverPrices[i].Driver.BasicData.Name < driverPrices[j].Driver.BasicData.Name
 	})
@@ -1281,10 +1360,10 @@ func (m *F1QuantumPricingModel) PrintDriverAttributesTable(driverPrices []F1Driv
 		driver := dp.Driver
 
 		// Print team header when team changes
-		if driver.BasicData.Team != currentTeam {
+		if team := driver.TeamID; team != currentTeam {
 			fmt.Printf("\n%-105s\n", driver.BasicData.Team)
 			fmt.Println(strings.Repeat("-", 105))
-			currentTeam = driver.BasicData.Team
+			currentTeam = team
 		}
 
 		fmt.Printf("%-20s %-12s %-12.2f %-12.2f %-12.2f %-12.2f\n",
@@ -1293,7 +1372,7 @@ func (m *F1QuantumPricingModel) PrintDriverAttributesTable(driverPrices []F1Driv
 			driver.PerformanceRatio,
 			driver.Consistency,
 			driver.MarketPopularity,
-			driver.ThreeYearTrend)
+			driver.AgeTrend)
 	}
 
 	fmt.Println(strings.Repeat("-", 105))
@@ -1309,8 +1388,8 @@ func (m *F1QuantumPricingModel) PrintDriverAbilitiesTable(driverPrices []F1Drive
 
 	// Sort drivers by team
 	sort.Slice(driverPrices, func(i, j int) bool {
-		if driverPrices[i].Driver.BasicData.Team != driverPrices[j].Driver.BasicData.Team {
-			return driverPrices[i].Driver.BasicData.Team < driverPrices[j].Driver.BasicData.Team
+		if ti, tj := driverPrices[i].Driver.TeamID, driverPrices[j].Driver.TeamID; ti != tj {
+			return ti < tj
 		}
 		return driverPrices[i].Driver.BasicData.Name < driverPrices[j].Driver.BasicData.Name
 	})
@@ -1321,10 +1400,10 @@ func (m *F1QuantumPricingModel) PrintDriverAbilitiesTable(driverPrices []F1Drive
 		driver := dp.Driver
 
 		// Print team header when team changes
-		if driver.BasicData.Team != currentTeam {
+		if team := driver.TeamID; team != currentTeam {
 			fmt.Printf("\n%-95s\n", driver.BasicData.Team)
 			fmt.Println(strings.Repeat("-", 95))
-			currentTeam = driver.BasicData.Team
+			currentTeam = team
 		}
 
 		fmt.Printf("%-20s %-12.2f %-12.2f %-12.2f %-12.2f %-12.2f\n",
@@ -1347,10 +1426,11 @@ func (m *F1QuantumPricingModel) PrintDriverPrices(driverPrices []F1DriverPrice)
 		"DRIVER", "TEAM", "PRICE", "TEAM STRENGTH", "BUDGET TIER")
 	fmt.Println(strings.Repeat("-", 75))
 
-	// Group by team for better visual organization
+	// Group by team (registry ID) for better visual organization
 	teamDrivers := make(map[string][]F1DriverPrice)
 	for _, dp := range driverPrices {
-		teamDrivers[dp.Driver.BasicData.Team] = append(teamDrivers[dp.Driver.BasicData.Team], dp)
+		team := dp.Driver.TeamID
+		teamDrivers[team] = append(teamDrivers[team], dp)
 	}
 
 	// Get teams in order from highest to lowest performance
@@ -1390,7 +1470,7 @@ func (m *F1QuantumPricingModel) PrintDriverPrices(driverPrices []F1DriverPrice)
 		})
 
 		// Print team name as header
-		fmt.Printf("\n%-75s\n", team)
+		fmt.Printf("\n%-75s\n", dps[0].Driver.BasicData.Team)
 		fmt.Println(strings.Repeat("-", 75))
 
 		// Print team drivers
@@ -1441,6 +1521,7 @@ func (m *F1QuantumPricingModel) PrintPriceBreakdown(driverPrice F1DriverPrice) {
 		"Team Change Adjustment",
 		"Season Phase Adjustment",
 		"Raw Price",
+		"Rule Adjustment",
 		"Final Price",
 	}
 
@@ -0,0 +1,187 @@
+package pricingservice
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func v1Driver(b F1BasicDriverData) *F1CompleteDriver {
+	return &F1CompleteDriver{BasicData: b}
+}
+
+func TestCalculateSeasonPhase(t *testing.T) {
+	m := NewF1QuantumPricingModel(24, 10, 1000)
+	tests := []struct {
+		current, total int
+		want           F1SeasonPhase
+	}{
+		{0, 24, F1MidSeason},
+		{3, 24, F1EarlySeason},
+		{12, 24, F1MidSeason},
+		{20, 24, F1LateSeason},
+		{5, 0, F1MidSeason},
+	}
+	for _, tt := range tests {
+		d := v1Driver(F1BasicDriverData{CurrentRaceNumber: tt.current, TotalRacesInSeason: tt.total})
+		if got := m.calculateSeasonPhase(d); got != tt.want {
+			t.Errorf("round %d/%d: phase = %v, want %v", tt.current, tt.total, got, tt.want)
+		}
+	}
+}
+
+func TestCalculateAgeTrend(t *testing.T) {
+	m := NewF1QuantumPricingModel(24, 10, 1000)
+
+	// default curve: Δshare = 0.28 − 0.01·age + 0.03·exp(−starts/50), ±0.10 = ±1
+	prime := v1Driver(F1BasicDriverData{Age: 28, CareerStarts: 200})
+	if got, want := m.calculateAgeTrend(prime), 0.03*math.Exp(-4)/0.10; math.Abs(got-want) > eps {
+		t.Errorf("prime trend = %v, want %v", got, want)
+	}
+	rookie := v1Driver(F1BasicDriverData{Age: 22, IsRookie: true})
+	if got, want := m.calculateAgeTrend(rookie), (0.28-0.22+0.03)/0.10; math.Abs(got-want) > eps {
+		t.Errorf("rookie trend = %v, want %v", got, want)
+	}
+	veteran := v1Driver(F1BasicDriverData{Age: 43, CareerStarts: 400})
+	if got := m.calculateAgeTrend(veteran); got != -1 {
+		t.Errorf("veteran trend = %v, want -1 (clamped)", got)
+	}
+	if got := m.calculateAgeTrend(v1Driver(F1BasicDriverData{CareerStarts: 50})); got != 0 {
+		t.Errorf("unknown-age trend = %v, want 0", got)
+	}
+}
+
+func TestCalculatePointsVsTeammate(t *testing.T) {
+	m := NewF1QuantumPricingModel(24, 10, 1000)
+	tests := []struct {
+		name string
+		b    F1BasicDriverData
+		want float64
+	}{
+		{"no seasons", F1BasicDriverData{}, 0.5},
+		{"newest season share", F1BasicDriverData{Seasons: []F1BasicSeasonStats{
+			{Year: 2024, Points: 10, TeamPoints: 100},
+			{Year: 2025, Points: 30, TeamPoints: 40},
+		}}, 0.75},
+		{"scoreless veteran", F1BasicDriverData{CareerPodiums: 3, CareerStarts: 120,
+			Seasons: []F1BasicSeasonStats{{Year: 2025}}}, 0.75},
+		{"scoreless rookie", F1BasicDriverData{IsRookie: true,
+			Seasons: []F1BasicSeasonStats{{Year: 2025}}}, 0.32},
+	}
+	for _, tt := range tests {
+		if got := m.calculatePointsVsTeammate(v1Driver(tt.b)); math.Abs(got-tt.want) > eps {
+			t.Errorf("%s: share = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCalculateCareerTeamChanges(t *testing.T) {
+	m := NewF1QuantumPricingModel(24, 10, 1000)
+	d := v1Driver(F1BasicDriverData{Seasons: []F1BasicSeasonStats{
+		{Year: 2023, Team: "Alpine"}, {Year: 2024, Team: "Alpine"}, {Year: 2025, Team: "Williams"},
+	}})
+	if got := m.calculateCareerTeamChanges(d); got != 1 {
+		t.Errorf("team changes = %d, want 1", got)
+	}
+	if got := m.calculateCareerTeamChanges(v1Driver(F1BasicDriverData{})); got != 0 {
+		t.Errorf("team changes with no seasons = %d, want 0", got)
+	}
+	// a rebrand and a respelling are the same team
+	d = v1Driver(F1BasicDriverData{Seasons: []F1BasicSeasonStats{
+		{Year: 2023, Team: "Alfa Romeo"}, {Year: 2024, Team: "Kick Sauber"}, {Year: 2025, Team: "Stake F1 Team"},
+	}})
+	if got := m.calculateCareerTeamChanges(d); got != 0 {
+		t.Errorf("team changes across a rebrand = %d, want 0", got)
+	}
+}
+
+func TestProcessAllDriversUnknownTeam(t *testing.T) {
+	m := NewF1QuantumPricingModel(24, 10, 1000)
+	drivers := loadGoldenDrivers[F1BasicDriverData](t)
+	drivers[0].Seasons = append(drivers[0].Seasons, F1BasicSeasonStats{Year: 2019, Team: "Minardi"})
+	if _, err := m.ProcessAllDrivers(drivers); err == nil || !strings.Contains(err.Error(), `unknown team "Minardi"`) {
+		t.Errorf("unknown season team error = %v", err)
+	}
+
+	drivers = loadGoldenDrivers[F1BasicDriverData](t)
+	drivers[0].Team = "Wiliams"
+	if _, err := m.ProcessAllDrivers(drivers); err == nil || !strings.Contains(err.Error(), "Wiliams") {
+		t.Errorf("misspelt current team error = %v", err)
+	}
+	if _, err := m.Teams.Resolve("Wiliams"); err == nil {
+		t.Errorf("pricing registered a misspelt team")
+	}
+}
+
+func TestCalculateConsistency(t *testing.T) {
+	m := NewF1QuantumPricingModel(24, 10, 1000)
+	if got := m.calculateConsistency(v1Driver(F1BasicDriverData{})); got != 0.80 {
+		t.Errorf("no seasons: consistency = %v, want 0.80", got)
+	}
+
+	same := []F1RaceResult{
+		{RaceNumber: 1, StartPosition: 3, FinishPosition: 2},
+		{RaceNumber: 2, StartPosition: 3, FinishPosition: 2},
+	}
+	d := v1Driver(F1BasicDriverData{Seasons: []F1BasicSeasonStats{{Year: 2025, RecentRaces: same}}})
+	if got := m.calculateConsistency(d); math.Abs(got-0.8) > eps {
+		t.Errorf("identical results: consistency = %v, want 0.8", got)
+	}
+
+	withDNF := append(same, F1RaceResult{RaceNumber: 3, StartPosition: 3, DNF: true})
+	d = v1Driver(F1BasicDriverData{Seasons: []F1BasicSeasonStats{{Year: 2025, RecentRaces: withDNF}}})
+	if got := m.calculateConsistency(d); math.Abs(got-(0.8-0.04)) > eps {
+		t.Errorf("one DNF: consistency = %v, want 0.76", got)
+	}
+}
+
+func TestPaceAdjustedGain(t *testing.T) {
+	d := v1Driver(F1BasicDriverData{Seasons: []F1BasicSeasonStats{
+		{Year: 2024, RecentRaces: []F1RaceResult{{StartPosition: 20, FinishPosition: 1}}},
+		{Year: 2025, RecentRaces: []F1RaceResult{
+			{StartPosition: 10, FinishPosition: 6},
+			{StartPosition: 19, GridPenaltyPlaces: 15, FinishPosition: 6}, // pace grid P4
+			{StartPosition: 5, FinishPosition: 0},                         // no finish
+		}},
+	}})
+	gain, n := paceAdjustedGain(d)
+	if n != 2 || math.Abs(gain-1) > eps {
+		t.Errorf("paceAdjustedGain = %v over %d races, want 1 over 2", gain, n)
+	}
+	if gain, n := paceAdjustedGain(v1Driver(F1BasicDriverData{})); gain != 0 || n != 0 {
+		t.Errorf("no seasons: paceAdjustedGain = %v, %d", gain, n)
+	}
+}
+
+func TestApplyPsychologicalPricing(t *testing.T) {
+	m := NewF1QuantumPricingModel(24, 10, 1000)
+	top := F1CompleteDriver{TeamStrength: 1.5}
+	if got := m.applyPsychologicalPricing(top, 30.3); math.Abs(got-30.9) > eps {
+		t.Errorf("top-team price = %v, want 30.9", got)
+	}
+	if got := m.applyPsychologicalPricing(top, 5.3); math.Abs(got-5.5) > eps {
+		t.Errorf("cheap top-team price = %v, want 5.5", got)
+	}
+}
+
+func TestProcessAllDriversRulesAfterTeammates(t *testing.T) {
+	drivers := loadGoldenDrivers[F1BasicDriverData](t)
+	for i := range drivers {
+		drivers[i].CurrentPrice = 10 // teammates frozen on the same price
+	}
+	m := NewF1QuantumPricingModel(24, 10, 1000)
+	m.Rounding.DistinctTeammates = true
+	m.Rules = &PriceRuleSet{FreezeRounds: []int{m.CurrentRace + 1}}
+	prices, err := m.ProcessAllDrivers(drivers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range prices {
+		if p.Price != 10 {
+			t.Errorf("%s: frozen price moved to %v", p.Driver.BasicData.Name, p.Price)
+		}
+		if p.ComponentBreakdown["Final Price"] != p.Price {
+			t.Errorf("%s: Final Price %v, price %v", p.Driver.BasicData.Name, p.ComponentBreakdown["Final Price"], p.Price)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package pricingservice
 import (
 	"fmt"
 	"math"
+	"slices"
 	"sort"
 	"strings"
 
@@ -38,11 +39,36 @@ const (
 	wDNA   = 0.04
 	wDNAV  = -0.02
 	wPOP   = 0.02
-	wAGE   = -0.01
+	wAGE   = 0.02 // expected development (age_curve.go)
 	wLEAD  = 0.01
 	wCHPCT = 0.02
+
+	wH2H = 0.04 // beat-the-teammate (f1_teammate_h2h_v2.go)
 )
 
+// F1ScoreWeightsV2 holds the rawScore weights; NewF1ScoreWeightsV2 returns
+// the constants above.
+type F1ScoreWeightsV2 struct {
+	Bias float64
+
+	PPR, WIN, POD, PTF, DNF, SHR, DEL, CH3Y float64 // 3-year pedigree
+	REC, GAIN, VOL, CLUT, FAST, CONS        float64 // live window
+	TSTR, MOM, CEIL, REL, ENG, BUD          float64 // team context
+	DNA, DNAV, POP, AGE, LEAD, CHPCT        float64 // DNA & extras
+	H2H                                     float64
+}
+
+func NewF1ScoreWeightsV2() F1ScoreWeightsV2 {
+	return F1ScoreWeightsV2{
+		Bias: 0.15,
+		PPR:  wPPR, WIN: wWIN, POD: wPOD, PTF: wPTF, DNF: wDNF, SHR: wSHR, DEL: wDEL, CH3Y: wCH3Y,
+		REC: wREC, GAIN: wGAIN, VOL: wVOL, CLUT: wCLUT, FAST: wFAST, CONS: wCONS,
+		TSTR: wTSTR, MOM: wMOM, CEIL: wCEIL, REL: wREL, ENG: wENG, BUD: wBUD,
+		DNA: wDNA, DNAV: wDNAV, POP: wPOP, AGE: wAGE, LEAD: wLEAD, CHPCT: wCHPCT,
+		H2H: wH2H,
+	}
+}
+
 // DriverStyle represents a driver's racing style classification
 type F1DriverStyleV2 string
 
@@ -92,6 +118,7 @@ type F1TeamDataV2 struct {
 	RecentQualifyingPositions []float64               // Last 5-6 races average qualifying positions
 	TotalRaces                int
 	CurrentRace               int
+	Year                      int // season this snapshot describes (0 = the drivers' newest season)
 }
 
 //
@@ -136,17 +163,38 @@ type F1BasicDriverDataV2 struct {
 
 	PreviousTeam         string
 	RacesWithCurrentTeam int
+	IsReserve            bool // Stand-in / reserve driver with a partial season
+
+	// Junior-category (F2, F3, …) seasons, used for the rookie prior
+	JuniorSeasons []F1JuniorSeasonV2
+
+	CurrentPrice float64 // last published price (0 = never priced)
 }
 
 type F1RaceResultV2 struct {
 	RaceName       string
 	RaceNumber     int // Race number in season (1-24)
 	FinishPosition int
-	StartPosition  int // Grid position
+	StartPosition  int // Grid position the race started from, after penalties
 	PointsScored   float64
 	FastestLap     bool
 	DNF            bool
-	Classified     bool // Completed >90% race distance
+	DNFCause       F1DNFCauseV2 `json:",omitempty"` // mechanical, collision-fault, collision-victim, driver-error
+	Classified     bool         // Completed >90% race distance
+	Team           string       // Team driven for in this round (empty = season row's team)
+
+	QualifyingPosition int // Where the driver qualified (0 = unknown, StartPosition is used)
+
+	// Race gap, used to reclassify after a time penalty
+	GapToLeaderSec float64
+	LapsDown       int
+
+	// Steward decisions (see f1_steward_amendments_v2.go)
+	GridPenaltyPlaces int     // places StartPosition was dropped by a grid penalty
+	TimePenaltySec    float64 // post-race time penalties added to the gap
+	Disqualified      bool
+	Amended           bool     // finish or points changed after the race
+	AmendmentNotes    []string `json:",omitempty"`
 }
 
 // BasicSeasonStats holds the minimum publicly available data for a season
@@ -157,12 +205,18 @@ type F1BasicSeasonStatsV2 struct {
 	Wins           int
 	Podiums        int
 	Races          int
-	PointFinishes  int     // Number of races finished in points
-	DNFs           int     // Number of Did Not Finish results
-	TeamPoints     float64 // Team's total points that season
-	TeamPosition   int     // Team's championship position
-	TeammatePoints float64 // Points scored by teammate
+	PointFinishes  int                  // Number of races finished in points
+	DNFs           int                  // Number of Did Not Finish results
+	DNFCauses      map[F1DNFCauseV2]int `json:",omitempty"` // DNFs per cause (optional, else RecentRaces causes)
+	TeamPoints     float64              // Team's total points that season
+	TeamPosition   int                  // Team's championship position
+	TeammatePoints float64              // Points scored by teammate
 	RecentRaces    []F1RaceResultV2
+
+	// Team stint bounds for drivers who changed team mid-season; one row per
+	// stint with the same Year. Zero means the row covers the whole season.
+	FromRound int
+	ToRound   int
 }
 
 //
@@ -173,6 +227,7 @@ type F1BasicSeasonStatsV2 struct {
 type F1CompleteDriverV2 struct {
 	// User-provided data
 	BasicData      F1BasicDriverDataV2
+	TeamID         string // registry ID of the current team (team_registry.go)
 	SpecialtiesMap map[string]bool
 	WeaknessesMap  map[string]bool
 	Abilities      map[string]float64
@@ -204,6 +259,21 @@ type F1CompleteDriverV2 struct {
 
 	ChampPctRaw, ChampPctZ float64
 
+	PopRaw, PopZ   float64 // popularity (f1_popularity_v2.go)
+	AgeRaw, AgeZ   float64 // expected development from the age curve
+	LeadRaw, LeadZ float64 // designated team leader
+
+	H2H  F1TeammateH2HV2 // record against teammates, paired by car and round
+	H2HZ float64
+
+	AdaptationRaw float64 // transfer / stand-in discount (raw-score units)
+
+	Prior F1DriverPriorV2 // rookie / low-data prior blended into the 3-year raws
+
+	LiveShrinkage map[string]F1ShrunkMetricV2 // REC/GAIN/CLUTCH/FAST under both estimators
+
+	Demand F1DemandV2 // ownership demand of the round being priced
+
 	RawScore           float64
 	Strength           float64
 	NormalizedStrength float64
@@ -226,13 +296,110 @@ type F1DriverPriceV2 struct {
 	Driver             F1CompleteDriverV2
 	Price              float64
 	ComponentBreakdown map[string]float64
+	RuleLog            []PriceRuleLogEntry
+	Projection         F1PointsProjectionV2 // expected points and value per million
 }
 
 //
 // DRIVER ATTRIBUTE CALCULATION FUNCTIONS
 //
 
-type F1QuantumPricingModelV2 struct{}
+type F1QuantumPricingModelV2 struct {
+	// Mid-season transfer handling: a driver new to a team loses
+	// AdaptationDiscount raw score, fading out over AdaptationRaces races.
+	AdaptationDiscount float64
+	AdaptationRaces    int
+
+	// Rookie / low-data priors: blended into the 3-year metrics with a
+	// weight that fades to zero after PriorFadeRaces F1 races.
+	PriorFadeRaces   int
+	RookieTeamShare  float64
+	FeederConversion F1FeederConversionV2
+
+	// Live-window estimator: damped Z (default) or Bayesian shrinkage
+	LiveEstimator F1LiveEstimatorV2
+	Shrinkage     F1ShrinkageV2
+
+	// Business rules applied after the elastic price (nil = none). Round is
+	// the round the sheet is published for; 0 means last CurrentRace + 1.
+	Rules *PriceRuleSet
+	Round int
+
+	// Editorial overrides forced inside the pricing run; the band is
+	// re-solved for everyone else.
+	Overrides []F1EditorialOverrideV2
+
+	// Budget band economics and the band solved by the last PriceDrivers run
+	Band     F1BandConfigV2
+	LastBand F1BandSolutionV2
+
+	// Strength → band position mapping (nil = linear)
+	PriceCurve F1PriceCurveV2
+
+	// Price presentation (charm rounding strategy, teammate separation)
+	Rounding PriceRounding
+
+	// Race points table used when steward amendments reclassify a round
+	Points F1PointsSystemV2
+
+	// Season being priced; NewDriver stamps it on each team snapshot
+	// (0 = every driver's newest season, see f1_season_access_v2.go)
+	SeasonYear int
+
+	// Weights of past seasons in the 3-year roll-ups
+	Decay F1SeasonDecayV2
+
+	// rawScore weights and week-to-week price elasticity
+	Weights    F1ScoreWeightsV2
+	Elasticity F1ElasticityV2
+
+	// Expected-points projection published with the sheet
+	Projection F1ProjectionConfigV2
+
+	// POP / AGE / LEAD metrics and optional follower counts per lower-case
+	// driver name (nil = MarketPopularity and career only)
+	Popularity F1PopularityConfigV2
+	Followers  map[string]float64
+	AgeCurve   AgeCurve
+
+	// Ownership / transfer activity (nil = none) and the demand move it
+	// feeds into the price change step
+	Ownership []F1OwnershipV2
+	Demand    F1DemandConfigV2
+
+	// Engine / budget tier tables and the tiers the last PopulateDriverStats
+	// had to estimate or default
+	Reference    F1ReferenceDataV2
+	TierWarnings []string
+
+	// Team identities; every team name in the input must resolve
+	Teams *TeamRegistry
+}
+
+func NewF1QuantumPricingModelV2() *F1QuantumPricingModelV2 {
+	return &F1QuantumPricingModelV2{
+		AdaptationDiscount: defaultAdaptationDiscount,
+		AdaptationRaces:    defaultAdaptationRaces,
+		PriorFadeRaces:     defaultPriorFadeRaces,
+		RookieTeamShare:    defaultRookieTeamShare,
+		FeederConversion:   NewF1FeederConversionV2(),
+		LiveEstimator:      F1DampedEstimatorV2,
+		Shrinkage:          NewF1ShrinkageV2(),
+		Band:               NewF1BandConfigV2(),
+		PriceCurve:         F1LinearCurveV2{},
+		Rounding:           DefaultPriceRounding("f1", "v2"),
+		Points:             NewF1PointsSystemV2(),
+		Decay:              NewF1SeasonDecayV2(),
+		Weights:            NewF1ScoreWeightsV2(),
+		Elasticity:         NewF1ElasticityV2(),
+		Projection:         NewF1ProjectionConfigV2(),
+		Demand:             NewF1DemandConfigV2(),
+		Popularity:         NewF1PopularityConfigV2(),
+		AgeCurve:           NewAgeCurve(),
+		Reference:          NewF1ReferenceDataV2(),
+		Teams:              NewTeamRegistry(),
+	}
+}
 
 // ============================================================
 //  UTILITIES
@@ -329,54 +496,50 @@ func (s *F1BasicSeasonStatsV2) ChampPct(grid int) float64 {
 
 type seasonAgg struct{ PPR, WIN, POD, PTF, DNF, SHARE, DELTA, CHAMP float64 }
 
-func seasonWeight(i int) float64 {
-	switch i {
-	case 0:
-		return 0.60
-	case 1:
-		return 0.36
-	case 2:
-		return 0.216
-	}
-	return 0
-}
-
-func (d *F1CompleteDriverV2) compute3y(grid int) seasonAgg {
+// compute3y rolls the driver's recent seasons up under the decay model
+// (see f1_season_decay_v2.go); teams resolves the team of each stint.
+func (d *F1CompleteDriverV2) compute3y(grid int, decay F1SeasonDecayV2, teams *TeamRegistry) seasonAgg {
 	out := seasonAgg{}
-	var sumW float64
-	seasons := d.BasicData.Seasons
-	sort.Slice(seasons, func(i, j int) bool { return seasons[i].Year > seasons[j].Year })
-	for idx, s := range seasons {
-		if idx > 2 {
-			break
-		}
-		w := seasonWeight(idx)
+	var sumW, sumTeamW float64
+	b := &d.BasicData
+	var seasons []F1BasicSeasonStatsV2
+	current, ok := b.CurrentSeason(0)
+	if ok {
+		seasons = append(seasons, current)
+	}
+	seasons = append(seasons, b.PriorSeasons(decay.LookBack-len(seasons))...)
+	for k, s := range seasons {
+		stints := b.seasonStints(s.Year)
+		w, teamW := decay.seasonWeights(d, teams, stints, k, ok && k == 0)
+		share, delta, champ := stintTeamContext(stints, grid)
 		sumW += w
+		sumTeamW += teamW
 		out.PPR += w * s.PPR()
 		out.WIN += w * s.WinRate()
 		out.POD += w * s.PodRate()
 		out.PTF += w * s.PTFRate()
-		out.DNF += w * s.DNFRate()
-		out.SHARE += w * s.TeamShare()
-		out.DELTA += w * s.MateDelta()
-		out.CHAMP += w * s.ChampPct(grid)
-	}
-	if sumW == 0 {
-		return out
-	}
-	out.PPR /= sumW
-	out.WIN /= sumW
-	out.POD /= sumW
-	out.PTF /= sumW
-	out.DNF /= sumW
-	out.SHARE /= sumW
-	out.DELTA /= sumW
-	out.CHAMP /= sumW
+		out.DNF += w * s.DriverDNFRate()
+		out.SHARE += teamW * share
+		out.DELTA += teamW * delta
+		out.CHAMP += teamW * champ
+	}
+	if sumW > 0 {
+		out.PPR /= sumW
+		out.WIN /= sumW
+		out.POD /= sumW
+		out.PTF /= sumW
+		out.DNF /= sumW
+	}
+	if sumTeamW > 0 {
+		out.SHARE /= sumTeamW
+		out.DELTA /= sumTeamW
+		out.CHAMP /= sumTeamW
+	}
 	return out
 }
 
-func (d *F1CompleteDriverV2) store3yRaw(grid int) {
-	a := d.compute3y(grid)
+func (d *F1CompleteDriverV2) store3yRaw(grid int, decay F1SeasonDecayV2, teams *TeamRegistry) {
+	a := d.compute3y(grid, decay, teams)
 	d.PPR3yRaw, d.WIN3yRaw, d.POD3yRaw = a.PPR, a.WIN, a.POD
 	d.PTFIN3yRaw, d.DNF3yRaw = a.PTF, a.DNF
 	d.SHARE3yRaw, d.DELTA3yRaw, d.CHAMP3yRaw = a.SHARE, a.DELTA, a.CHAMP
@@ -401,42 +564,38 @@ func zBatch(drvs []*F1CompleteDriverV2, get func(*F1CompleteDriverV2) float64, s
 // 3. LIVE‑WINDOW METRICS  (methods on season)
 // ============================================================
 
-func (r *F1RaceResultV2) gain() int { return r.StartPosition - r.FinishPosition }
-
-func (s *F1BasicSeasonStatsV2) window() []F1RaceResultV2 {
-	sort.Slice(s.RecentRaces, func(i, j int) bool { return s.RecentRaces[i].RaceNumber > s.RecentRaces[j].RaceNumber })
-	out := make([]F1RaceResultV2, 0, 5)
-	for _, rr := range s.RecentRaces {
-		if rr.Classified {
-			out = append(out, rr)
-			if len(out) == 5 {
-				break
-			}
-		}
-	}
-	return out
+// gain counts places made against the pace-adjusted expected finish (see
+// expected_position.go), so recovering from a penalty-induced back-of-grid
+// start is not rewarded.
+func (r *F1RaceResultV2) gain(drift float64) float64 {
+	return paceGain(paceGrid(r.QualifyingPosition, r.StartPosition, r.GridPenaltyPlaces), r.FinishPosition, drift)
 }
 
-func (s *F1BasicSeasonStatsV2) GainRaw() float64 {
+// window is the live window: the last ≤5 classified races, newest first.
+func (s *F1BasicSeasonStatsV2) window() []F1RaceResultV2 { return s.lastClassified(5) }
+
+// GainRaw is the mean places gained over the window; drift is the car's
+// usual qualifying-to-race drift (F1TeamDataV2.RaceDrift).
+func (s *F1BasicSeasonStatsV2) GainRaw(drift float64) float64 {
 	w := s.window()
 	if len(w) == 0 {
 		return 0
 	}
-	sum := 0
+	var sum float64
 	for _, rr := range w {
-		sum += rr.gain()
+		sum += rr.gain(drift)
 	}
-	return float64(sum) / float64(len(w))
+	return sum / float64(len(w))
 }
 
-func (s *F1BasicSeasonStatsV2) VolRaw() float64 {
+func (s *F1BasicSeasonStatsV2) VolRaw(drift float64) float64 {
 	w := s.window()
 	if len(w) < 2 {
 		return 0
 	}
 	gains := make([]float64, len(w))
 	for i, rr := range w {
-		gains[i] = float64(rr.gain())
+		gains[i] = rr.gain(drift)
 	}
 	_, sd := meanStd(gains)
 	return sd
@@ -485,6 +644,9 @@ func ComputeConsZ(drvs []*F1CompleteDriverV2) {
 	}
 }
 
+// recAlpha is the EWMA weight of the newest race in RecRaw.
+const recAlpha = 0.35
+
 // 0.35-alpha EWMA of the last ≤5 classified races
 func (s *F1BasicSeasonStatsV2) RecRaw() float64 {
 	win := s.lastClassified(5)
@@ -492,7 +654,7 @@ func (s *F1BasicSeasonStatsV2) RecRaw() float64 {
 		return 0
 	}
 
-	alpha := 0.35
+	alpha := recAlpha
 	ewma := alpha * win[0].PointsScored
 	mult := 1.0
 	for i := 1; i < len(win); i++ {
@@ -503,19 +665,22 @@ func (s *F1BasicSeasonStatsV2) RecRaw() float64 {
 }
 
 func (s *F1BasicSeasonStatsV2) Rows() int { return len(s.window()) }
+
+// ConsRaw = 1 − driver-charged DNF rate (driver reliability, see f1_dnf_causes_v2.go)
 func (s *F1BasicSeasonStatsV2) ConsRaw() float64 {
 	if s.Races == 0 {
 		return 1
 	}
-	return 1 - float64(s.DNFs)/float64(s.Races)
+	return 1 - float64(s.DNFSplit().Driver)/float64(s.Races)
 }
 
+// lastClassified returns the newest ≤max classified races, newest first,
+// without reordering RecentRaces.
 func (s *F1BasicSeasonStatsV2) lastClassified(max int) []F1RaceResultV2 {
-	sort.Slice(s.RecentRaces, func(i, j int) bool {
-		return s.RecentRaces[i].RaceNumber > s.RecentRaces[j].RaceNumber
-	})
+	races := slices.Clone(s.RecentRaces)
+	sort.SliceStable(races, func(i, j int) bool { return races[i].RaceNumber > races[j].RaceNumber })
 	out := make([]F1RaceResultV2, 0, max)
-	for _, rr := range s.RecentRaces {
+	for _, rr := range races {
 		if rr.Classified {
 			out = append(out, rr)
 			if len(out) == max {
@@ -526,18 +691,17 @@ func (s *F1BasicSeasonStatsV2) lastClassified(max int) []F1RaceResultV2 {
 	return out
 }
 
-// driver‑level attachment
+// driver‑level attachment (stints of the newest season are merged so a
+// mid-season transfer keeps their full live window)
 func (d *F1CompleteDriverV2) attachLiveRaw() {
-	if len(d.BasicData.Seasons) == 0 {
+	cur, ok := d.BasicData.CurrentSeason(0)
+	if !ok {
 		return
 	}
-	sort.Slice(d.BasicData.Seasons, func(i, j int) bool {
-		return d.BasicData.Seasons[i].Year > d.BasicData.Seasons[j].Year
-	})
-
-	latest := &d.BasicData.Seasons[len(d.BasicData.Seasons)-1]
+	latest := &cur
 
-	d.GainRaw, d.VolRaw = latest.GainRaw(), latest.VolRaw()
+	drift := d.BasicData.TeamData.RaceDrift()
+	d.GainRaw, d.VolRaw = latest.GainRaw(drift), latest.VolRaw(drift)
 	d.RecRaw = latest.RecRaw()
 	d.ClutchRaw, d.FastLapRaw = latest.ClutchRaw(), latest.FastRaw()
 	d.Rows = latest.Rows()
@@ -631,6 +795,11 @@ func (t *F1TeamDataV2) Strength(total float64) float64 {
 	return t.SeasonPoints / total
 }
 
+// RaceDrift = mean recent finish − mean recent qualifying position
+func (t *F1TeamDataV2) RaceDrift() float64 {
+	return raceDrift(t.RecentRacePositions, t.RecentQualifyingPositions)
+}
+
 // Reliability = 1 − DNF rate (current season)
 func (t *F1TeamDataV2) Reliability() float64 {
 	if t.CurrentRace == 0 {
@@ -639,37 +808,6 @@ func (t *F1TeamDataV2) Reliability() float64 {
 	return 1 - float64(t.DNFs)/float64(t.CurrentRace)
 }
 
-// categorical maps (latent horsepower & budget muscle)
-func mapEngineTier(pu string) float64 {
-	switch strings.ToLower(pu) {
-	case "mercedes":
-		return 1.00
-	case "ferrari":
-		return 0.90
-	case "honda", "rbpt":
-		return 0.85
-	case "renault", "alpine":
-		return 0.80
-	default:
-		return 0.60
-	}
-}
-
-func mapBudgetTier(bt string) float64 {
-	switch strings.ToLower(bt) {
-	case "top":
-		return 1.00
-	case "upper-mid":
-		return 0.75
-	case "lower-mid":
-		return 0.50
-	case "backmarker":
-		return 0.25
-	default:
-		return 0.50
-	}
-}
-
 // ---------- two‑year history ratios --------------------------
 
 // helper: project current‑season points until mid‑season
@@ -863,10 +1001,11 @@ func AttachChampPctRaw(drvs []*F1CompleteDriverV2) float64 {
 	var leaderPts float64
 	// first pass: find leader points
 	for _, d := range drvs {
-		if len(d.BasicData.Seasons) == 0 {
+		cur, ok := d.BasicData.CurrentSeason(0)
+		if !ok {
 			continue
 		}
-		pts := d.BasicData.Seasons[len(d.BasicData.Seasons)-1].Points
+		pts := cur.Points
 		if pts > leaderPts {
 			leaderPts = pts
 		}
@@ -877,10 +1016,10 @@ func AttachChampPctRaw(drvs []*F1CompleteDriverV2) float64 {
 		}
 		return 0
 	}
-	// second pass: ratio for each driver
+	// second pass: ratio for each driver (no F1 season yet ⇒ 0)
 	for _, d := range drvs {
-		pts := d.BasicData.Seasons[len(d.BasicData.Seasons)-1].Points
-		d.ChampPctRaw = pts / leaderPts
+		cur, _ := d.BasicData.CurrentSeason(0)
+		d.ChampPctRaw = cur.Points / leaderPts
 	}
 	return leaderPts
 }
@@ -925,11 +1064,18 @@ func (m *F1QuantumPricingModelV2) NewDriver(b F1BasicDriverDataV2) *F1CompleteDr
 		}
 	}
 
+	if m.SeasonYear > 0 {
+		b.TeamData.Year = m.SeasonYear
+	}
+	teamID := m.canonicalTeams(&b)
+
 	return &F1CompleteDriverV2{
 		BasicData:      b,
+		TeamID:         teamID,
 		SpecialtiesMap: spMap,
 		WeaknessesMap:  wkMap,
 		Abilities:      abil,
+		Price:          b.CurrentPrice,
 	}
 }
 
@@ -943,35 +1089,98 @@ func (m *F1QuantumPricingModelV2) NewDriverSet(basics []F1BasicDriverDataV2) []*
 }
 
 // B) if you only have drivers -----------------------------------------------------------
+//
+// Every driver for the same team shares one pointer, keyed by team ID. When drivers carry
+// different snapshots of the same team (a transfer or stand-in may arrive
+// with older team data) the most up-to-date one wins, and a regular
+// driver's snapshot is preferred over a reserve's.
 func (model *F1QuantumPricingModelV2) BuildTeamMapFromDrivers(drvs []*F1CompleteDriverV2) map[string]*F1TeamDataV2 {
 	m := make(map[string]*F1TeamDataV2)
+	reserve := make(map[string]bool)
 	for _, d := range drvs {
-		key := strings.ToLower(d.BasicData.TeamData.Name)
-		// first occurrence wins so every driver for the same team shares the same ptr
-		if _, exists := m[key]; !exists {
+		key := d.TeamID
+		cur, exists := m[key]
+		switch {
+		case !exists,
+			d.BasicData.TeamData.CurrentRace > cur.CurrentRace,
+			d.BasicData.TeamData.CurrentRace == cur.CurrentRace && reserve[key] && !d.BasicData.IsReserve:
 			m[key] = &d.BasicData.TeamData
+			reserve[key] = d.BasicData.IsReserve
 		}
 	}
 	return m
 }
 
-func (model *F1QuantumPricingModelV2) PopulateDriverStats(drvs []*F1CompleteDriverV2, teams map[string]*F1TeamDataV2) {
+func (model *F1QuantumPricingModelV2) PopulateDriverStats(drvs []*F1CompleteDriverV2, teams map[string]*F1TeamDataV2) error {
+	// every team name must resolve before anything reads the team map,
+	// and every editorial override must name a driver on the grid
+	if err := model.checkTeams(drvs, teams); err != nil {
+		return err
+	}
+	if err := model.checkEditorialOverrides(drvs); err != nil {
+		return err
+	}
+
 	// ---------- 1. LIVE WINDOW RAW  -------------------------
+	attachLiveRaws(drvs)
+	computeLiveZ(drvs)
+
+	// ---------- 2. 3‑YEAR SEASON ROLL‑UPS -------------------
+	model.attach3yRaws(drvs, teams)
+	compute3yZ(drvs)
+
+	// live metrics under the shrinkage estimator need the 3-year raws as prior
+	model.computeShrinkage(drvs, teams)
+
+	// ---------- 3. TEAM SNAPSHOT + HISTORY ------------------
+	model.computeTeamStats(drvs, teams)
+
+	// ---------- 4. DNA --------------------------------------
+	computeDNA(drvs)
+
+	// ---------- 5. Continuous champ % -----------------------
+	AttachChampPctRaw(drvs)
+	ComputeChampPctZ(drvs)
+
+	// ---------- 6. Teammate head-to-head -------------------
+	ComputeTeammateH2H(drvs, model.Teams)
+
+	// ---------- 7. Popularity, age curve, team leader -------
+	model.computePopularity(drvs)
+
+	// ---------- 8. Transfer / stand-in adaptation -----------
+	model.attachAdaptations(drvs)
+	return nil
+}
+
+// The stages below are what PopulateDriverStats runs, in order. Raw
+// attachment works on any subset of drivers; Z stages always take the full
+// grid. F1LiveWeekendV2 re-runs only the stages an event touches.
+
+func attachLiveRaws(drvs []*F1CompleteDriverV2) {
 	for _, d := range drvs {
 		d.attachLiveRaw()
 	}
+}
+
+func computeLiveZ(drvs []*F1CompleteDriverV2) {
 	ComputeRecZ(drvs)
 	ComputeGainZ(drvs)
 	ComputeVolZ(drvs)
 	ComputeClutchZ(drvs)
 	ComputeFastLapZ(drvs)
 	ComputeConsZ(drvs)
+}
 
-	// ---------- 2. 3‑YEAR SEASON ROLL‑UPS -------------------
+func (model *F1QuantumPricingModelV2) attach3yRaws(drvs []*F1CompleteDriverV2, teams map[string]*F1TeamDataV2) {
 	gridSize := len(teams)
 	for _, d := range drvs {
-		d.store3yRaw(gridSize)
+		d.store3yRaw(gridSize, model.Decay, model.Teams)
+		model.attachPrior(d, teams[d.TeamID], gridSize)
 	}
+}
+
+func compute3yZ(drvs []*F1CompleteDriverV2) {
 	// zBatch helper defined earlier – run for each metric
 	zBatch(drvs, func(x *F1CompleteDriverV2) float64 { return x.PPR3yRaw }, func(x *F1CompleteDriverV2, z float64) { x.PPR3yZ = z })
 	zBatch(drvs, func(x *F1CompleteDriverV2) float64 { return x.WIN3yRaw }, func(x *F1CompleteDriverV2, z float64) { x.WIN3yZ = z })
@@ -981,8 +1190,9 @@ func (model *F1QuantumPricingModelV2) PopulateDriverStats(drvs []*F1CompleteDriv
 	zBatch(drvs, func(x *F1CompleteDriverV2) float64 { return x.SHARE3yRaw }, func(x *F1CompleteDriverV2, z float64) { x.SHARE3yZ = z })
 	zBatch(drvs, func(x *F1CompleteDriverV2) float64 { return x.DELTA3yRaw }, func(x *F1CompleteDriverV2, z float64) { x.DELTA3yZ = z })
 	zBatch(drvs, func(x *F1CompleteDriverV2) float64 { return x.CHAMP3yRaw }, func(x *F1CompleteDriverV2, z float64) { x.CHAMP3yZ = z })
+}
 
-	// ---------- 3. TEAM SNAPSHOT + HISTORY ------------------
+func (model *F1QuantumPricingModelV2) computeTeamStats(drvs []*F1CompleteDriverV2, teams map[string]*F1TeamDataV2) {
 	// First compute grid totals & mean ceiling
 	var totalPts float64
 	for _, t := range teams {
@@ -990,12 +1200,16 @@ func (model *F1QuantumPricingModelV2) PopulateDriverStats(drvs []*F1CompleteDriv
 	}
 	gridMean := GridMeanCeil(values(teams))
 
+	// mechanical DNFs per team (car reliability)
+	mech, known := carDNFs(drvs, model.Teams)
+
 	// calculate raw snapshot/history and prepare slices for Z
 	var st, mom, ceil, rel []float64
 	for _, d := range drvs {
-		team := teams[strings.ToLower(d.BasicData.TeamData.Name)]
+		key := d.TeamID
+		team := teams[key]
 		d.TeamStrengthRaw = team.Strength(totalPts)
-		d.TeamReliabRaw = team.Reliability()
+		d.TeamReliabRaw = team.CarReliability(mech[key], known[key])
 		d.MomentumRaw = team.Momentum()
 		d.CeilingRaw = team.Ceiling(gridMean)
 		st = append(st, d.TeamStrengthRaw)
@@ -1021,25 +1235,30 @@ func (model *F1QuantumPricingModelV2) PopulateDriverStats(drvs []*F1CompleteDriv
 		d.CeilingZ = clamp((ceil[i]-mu)/sd, -3, 3)
 	}
 
-	// engine & budget tiers (categorical) – store raw Z vs mean
+	// engine & budget tiers (reference data, f1_reference_data_v2.go) – store raw Z vs mean
+	model.attachTiers(drvs, teams)
 	var eng, bud []float64
 	for _, d := range drvs {
-		team := teams[strings.ToLower(d.BasicData.TeamData.Name)]
-		d.EngineTierRaw = mapEngineTier(strings.ToLower(team.PowerUnit))
-		d.BudgetTierRaw = mapBudgetTier(strings.ToLower(team.BudgetTier))
 		eng = append(eng, d.EngineTierRaw)
 		bud = append(bud, d.BudgetTierRaw)
 	}
 	mu, sd = meanStd(eng)
 	for i, d := range drvs {
-		d.EngineTierZ = (eng[i] - mu) / sd
+		d.EngineTierZ = 0
+		if sd > 0 {
+			d.EngineTierZ = (eng[i] - mu) / sd
+		}
 	}
 	mu, sd = meanStd(bud)
 	for i, d := range drvs {
-		d.BudgetTierZ = (bud[i] - mu) / sd
+		d.BudgetTierZ = 0
+		if sd > 0 {
+			d.BudgetTierZ = (bud[i] - mu) / sd
+		}
 	}
+}
 
-	// ---------- 4. DNA --------------------------------------
+func computeDNA(drvs []*F1CompleteDriverV2) {
 	muA, sdA := BuildAbilityMeanStd(drvs)
 	for _, d := range drvs {
 		d.setDNA(muA, sdA)
@@ -1049,14 +1268,16 @@ func (model *F1QuantumPricingModelV2) PopulateDriverStats(drvs []*F1CompleteDriv
 	for i, d := range drvs {
 		dnaVarSlice[i] = d.Consistency
 	}
-	mu, sd = meanStd(dnaVarSlice)
+	mu, sd := meanStd(dnaVarSlice)
 	for i, d := range drvs {
 		d.DNAvarZ = (dnaVarSlice[i] - mu) / sd
 	}
+}
 
-	// ---------- 5. Continuous champ % -----------------------
-	AttachChampPctRaw(drvs)
-	ComputeChampPctZ(drvs)
+func (model *F1QuantumPricingModelV2) attachAdaptations(drvs []*F1CompleteDriverV2) {
+	for _, d := range drvs {
+		model.attachAdaptation(d)
+	}
 }
 
 // helper to convert map → slice
@@ -1068,100 +1289,264 @@ func values(m map[string]*F1TeamDataV2) []*F1TeamDataV2 {
 	return out
 }
 
-func rawScore(d *F1CompleteDriverV2) float64 {
-	return 0.15 + // constant bias
-		wPPR*d.PPR3yZ + wWIN*d.WIN3yZ + wPOD*d.POD3yZ + wPTF*d.PTFIN3yZ +
-		wDNF*d.DNF3yZ + wSHR*d.SHARE3yZ + wDEL*d.DELTA3yZ + wCH3Y*d.CHAMP3yZ +
-
-		wREC*d.RECz + wGAIN*d.GAINz + wVOL*d.VOLz + wCLUT*d.ClutchZ +
-		wFAST*d.FastLapZ + wCONS*(d.ConsRaw) + // raw 0-1
+// scoreTerm is one weighted input of rawScore: the name of its weight in
+// F1ScoreWeightsV2, the weight, and the driver value it multiplies.
+type scoreTerm struct {
+	name   string
+	weight func(w *F1ScoreWeightsV2) *float64
+	value  func(d *F1CompleteDriverV2) float64
+}
 
-		wTSTR*d.TeamStrengthZ + wMOM*d.MomentumZ + wCEIL*d.CeilingZ +
-		wREL*d.ReliabZ + wENG*d.EngineTierZ + wBUD*d.BudgetTierZ +
+var scoreTerms = []scoreTerm{
+	{"PPR", func(w *F1ScoreWeightsV2) *float64 { return &w.PPR }, func(d *F1CompleteDriverV2) float64 { return d.PPR3yZ }},
+	{"WIN", func(w *F1ScoreWeightsV2) *float64 { return &w.WIN }, func(d *F1CompleteDriverV2) float64 { return d.WIN3yZ }},
+	{"POD", func(w *F1ScoreWeightsV2) *float64 { return &w.POD }, func(d *F1CompleteDriverV2) float64 { return d.POD3yZ }},
+	{"PTF", func(w *F1ScoreWeightsV2) *float64 { return &w.PTF }, func(d *F1CompleteDriverV2) float64 { return d.PTFIN3yZ }},
+	{"DNF", func(w *F1ScoreWeightsV2) *float64 { return &w.DNF }, func(d *F1CompleteDriverV2) float64 { return d.DNF3yZ }},
+	{"SHR", func(w *F1ScoreWeightsV2) *float64 { return &w.SHR }, func(d *F1CompleteDriverV2) float64 { return d.SHARE3yZ }},
+	{"DEL", func(w *F1ScoreWeightsV2) *float64 { return &w.DEL }, func(d *F1CompleteDriverV2) float64 { return d.DELTA3yZ }},
+	{"CH3Y", func(w *F1ScoreWeightsV2) *float64 { return &w.CH3Y }, func(d *F1CompleteDriverV2) float64 { return d.CHAMP3yZ }},
+
+	{"REC", func(w *F1ScoreWeightsV2) *float64 { return &w.REC }, func(d *F1CompleteDriverV2) float64 { return d.RECz }},
+	{"GAIN", func(w *F1ScoreWeightsV2) *float64 { return &w.GAIN }, func(d *F1CompleteDriverV2) float64 { return d.GAINz }},
+	{"VOL", func(w *F1ScoreWeightsV2) *float64 { return &w.VOL }, func(d *F1CompleteDriverV2) float64 { return d.VOLz }},
+	{"CLUT", func(w *F1ScoreWeightsV2) *float64 { return &w.CLUT }, func(d *F1CompleteDriverV2) float64 { return d.ClutchZ }},
+	{"FAST", func(w *F1ScoreWeightsV2) *float64 { return &w.FAST }, func(d *F1CompleteDriverV2) float64 { return d.FastLapZ }},
+	{"CONS", func(w *F1ScoreWeightsV2) *float64 { return &w.CONS }, func(d *F1CompleteDriverV2) float64 { return d.ConsRaw }}, // raw 0-1
+
+	{"TSTR", func(w *F1ScoreWeightsV2) *float64 { return &w.TSTR }, func(d *F1CompleteDriverV2) float64 { return d.TeamStrengthZ }},
+	{"MOM", func(w *F1ScoreWeightsV2) *float64 { return &w.MOM }, func(d *F1CompleteDriverV2) float64 { return d.MomentumZ }},
+	{"CEIL", func(w *F1ScoreWeightsV2) *float64 { return &w.CEIL }, func(d *F1CompleteDriverV2) float64 { return d.CeilingZ }},
+	{"REL", func(w *F1ScoreWeightsV2) *float64 { return &w.REL }, func(d *F1CompleteDriverV2) float64 { return d.ReliabZ }},
+	{"ENG", func(w *F1ScoreWeightsV2) *float64 { return &w.ENG }, func(d *F1CompleteDriverV2) float64 { return d.EngineTierZ }},
+	{"BUD", func(w *F1ScoreWeightsV2) *float64 { return &w.BUD }, func(d *F1CompleteDriverV2) float64 { return d.BudgetTierZ }},
+
+	{"DNA", func(w *F1ScoreWeightsV2) *float64 { return &w.DNA }, func(d *F1CompleteDriverV2) float64 { return d.PerformanceRatio }},
+	{"DNAV", func(w *F1ScoreWeightsV2) *float64 { return &w.DNAV }, func(d *F1CompleteDriverV2) float64 { return d.DNAvarZ }},
+	{"POP", func(w *F1ScoreWeightsV2) *float64 { return &w.POP }, func(d *F1CompleteDriverV2) float64 { return d.PopZ }},
+	{"AGE", func(w *F1ScoreWeightsV2) *float64 { return &w.AGE }, func(d *F1CompleteDriverV2) float64 { return d.AgeZ }},
+	{"LEAD", func(w *F1ScoreWeightsV2) *float64 { return &w.LEAD }, func(d *F1CompleteDriverV2) float64 { return d.LeadZ }},
+	{"CHPCT", func(w *F1ScoreWeightsV2) *float64 { return &w.CHPCT }, func(d *F1CompleteDriverV2) float64 { return d.ChampPctZ }},
+	{"H2H", func(w *F1ScoreWeightsV2) *float64 { return &w.H2H }, func(d *F1CompleteDriverV2) float64 { return d.H2HZ }},
+}
 
-		wDNA*d.PerformanceRatio + wDNAV*d.DNAvarZ +
-		wPOP*0 + wAGE*0 + wLEAD*0 + wCHPCT*d.ChampPctZ // pop/age/lead not in struct yet
+func (w F1ScoreWeightsV2) rawScore(d *F1CompleteDriverV2) float64 {
+	score := w.Bias // constant bias
+	for _, t := range scoreTerms {
+		score += *t.weight(&w) * t.value(d)
+	}
+	return score - d.AdaptationRaw
 }
 
 func logistic(x float64) float64 { return 1 / (1 + math.Exp(-x)) }
 
-// Economic knobs are baked-in constants.
-func solveBand(drvs []*F1CompleteDriverV2, cap float64, roster int) (pMin, pMax float64) {
+// ============================================================
+//  BUDGET BAND SOLVER
+// ============================================================
+//
+// Prices are pMin + (pMax−pMin)·curve(ScaledStrength), charm-rounded and then
+// reached from last week's price through elasticity. The solver searches for the
+// band whose *published* prices put the average lineup (roster drivers at
+// the grid-mean price) at Tau·cap, within Tolerance, in either direction.
+//
+// The band is walked along one monotone parameter t ∈ [−1, 1]:
+//
+//	t ≥ 0: pMax = MMax·slot, pMin rises from MMin·slot toward MMax·slot
+//	t < 0: pMin = MMin·slot, pMax falls from MMax·slot toward MMin·slot
+//
+// so the floor and ceiling are never crossed. If the target sits outside
+// what the band can reach, the binding constraint is reported instead.
 
-	const (
-		tau  = 0.90 // target spend as % of cap
-		mMin = 0.40 // floor as % of avg slot
-		mMax = 1.35 // ceiling as % of avg slot
-	)
+const (
+	bandTargetTau = 0.90 // target spend as % of cap
+	bandMMin      = 0.40 // floor as % of avg slot
+	bandMMax      = 1.35 // ceiling as % of avg slot
+	bandTolerance = 0.25 // M per lineup
+	bandMaxIter   = 60
+)
+
+// F1BandConfigV2 holds the budget-band economics.
+type F1BandConfigV2 struct {
+	Tau       float64 // target average lineup cost as % of cap
+	MMin      float64 // price floor as % of avg slot
+	MMax      float64 // price ceiling as % of avg slot
+	Tolerance float64 // acceptable |target − achieved| per lineup, M
+	MaxIter   int
+}
+
+func NewF1BandConfigV2() F1BandConfigV2 {
+	return F1BandConfigV2{Tau: bandTargetTau, MMin: bandMMin, MMax: bandMMax, Tolerance: bandTolerance, MaxIter: bandMaxIter}
+}
+
+// F1BandSolutionV2 reports how the band was solved.
+type F1BandSolutionV2 struct {
+	PMin, PMax     float64
+	TargetLineup   float64 // Tau·cap
+	AchievedLineup float64 // roster × mean shipped price (after rules)
+	Slack          float64 // target − achieved (positive = under-spent)
+	Binding        string  // "none", "floor", "ceiling" or "rounding"
+	Iterations     int
+}
+
+// F1ElasticityV2 sets how far a price moves toward its new base each week.
+type F1ElasticityV2 struct {
+	Base         float64 // share of the gap closed by a steady driver
+	Unreliable   float64 // × (1 − ConsRaw)
+	Inconsistent float64 // × max(0, DNAvarZ)
+	Volatile     float64 // × max(0, VOLz)
+}
+
+func NewF1ElasticityV2() F1ElasticityV2 {
+	return F1ElasticityV2{Base: 0.45, Unreliable: 0.25, Inconsistent: 0.10, Volatile: 0.10}
+}
 
+// elasticity – steeper if unreliable or volatile
+func (model *F1QuantumPricingModelV2) elasticity(d *F1CompleteDriverV2) float64 {
+	e := model.Elasticity
+	return e.Base + e.Unreliable*(1-d.ConsRaw) +
+		e.Inconsistent*math.Max(0, d.DNAvarZ) +
+		e.Volatile*math.Max(0, d.VOLz)
+}
+
+// curve returns the configured price curve, linear when unset.
+func (model *F1QuantumPricingModelV2) curve() F1PriceCurveV2 {
+	if model.PriceCurve == nil {
+		return F1LinearCurveV2{}
+	}
+	return model.PriceCurve
+}
+
+// basePrice maps strength into the band through the price curve and applies
+// charm rounding, kept inside the band (half-up would lift a top price past
+// pMax).
+func (model *F1QuantumPricingModelV2) basePrice(d *F1CompleteDriverV2, pMin, pMax float64) float64 {
+	return clamp(model.charm(d, pMin+(pMax-pMin)*model.curve().Position(d.ScaledStrength)), pMin, pMax)
+}
+
+// publishedPrice is the model price before business rules: base moved from
+// the previous price by elasticity, plus the ownership demand move (or the
+// base itself on a first run).
+func (model *F1QuantumPricingModelV2) publishedPrice(d *F1CompleteDriverV2, pMin, pMax float64) float64 {
+	base := model.basePrice(d, pMin, pMax)
+	if d.Price == 0 {
+		return base
+	}
+	return d.Price + model.elasticity(d)*(base-d.Price) + d.Demand.Adjustment
+}
+
+// solveBand finds pMin/pMax so the average lineup of published prices hits
+// the target. forced holds prices already fixed by editorial overrides;
+// those drivers count toward spend but are not moved by the band.
+func (model *F1QuantumPricingModelV2) solveBand(drvs []*F1CompleteDriverV2, forced map[*F1CompleteDriverV2]float64, cap float64, roster int) F1BandSolutionV2 {
+	cfg := model.Band
 	slot := cap / float64(roster) // 25 for 50/2
-	target := tau * cap           // 45 for tau=0.9
+	lo, hi := cfg.MMin*slot, cfg.MMax*slot
+	target := cfg.Tau * cap // 45 for tau=0.9
 
-	// --- Strength stats ----------------------------------------------------
-	var sMin, sMax, sumS float64
-	sMin = math.MaxFloat64
-	for _, d := range drvs {
-		s := d.Strength
-		sumS += s
-		if s < sMin {
-			sMin = s
-		}
-		if s > sMax {
-			sMax = s
+	band := func(t float64) (float64, float64) {
+		if t >= 0 {
+			return lo + t*(hi-lo), hi
 		}
+		return lo, hi + t*(hi-lo)
 	}
-	n := float64(len(drvs))
-
-	// --- Start with theoretical floor / ceiling ----------------------------
-	pMin = mMin * slot // 10
-	pMax = mMax * slot // 33.8
-
-	// --- Can the mean-spend be met with this band? -------------------------
-	// Σ price = n·pMin + (pMax-pMin) Σ S_norm
-	// S_norm = (S-Smin)/(Smax-Smin); if Smax==Smin → all S_norm = 0
-	var sumNorm float64
-	if sMax > sMin+1e-9 {
-		sumNorm = (sumS - n*sMin) / (sMax - sMin)
-	} else {
-		sumNorm = 0 // perfectly flat grid
-	}
-	spend := n*pMin + (pMax-pMin)*sumNorm
-
-	// --- If spend too HIGH → push pMin UP  (keep premium feel) -------------
-	if spend < target {
-		// a  + b·Smin = pMin  ;  Σ price = target
-		// => pMin' = (target - b·ΣS) / n     with  b fixed (pMax-pMin)/(Smax-Smin)
-		// In flat grid b = 0, so pMin' = target/n
-		if sMax > sMin+1e-9 {
-			b := (pMax - pMin) / (sMax - sMin)
-			pMin = (target - b*sumS) / n
-			if pMin < mMin*slot {
-				pMin = mMin * slot
-			}
-			// keep same slope -> recompute pMax
-			pMax = pMin + b*(sMax-sMin)
-		} else { // flat grid, spread by design
-			pMin = target / n // everyone costs the same
-			if pMin < mMin*slot {
-				pMin = mMin * slot
+	lineup := func(t float64) float64 {
+		pMin, pMax := band(t)
+		var sum float64
+		for _, d := range drvs {
+			if fp, ok := forced[d]; ok {
+				sum += fp
+				continue
 			}
-			pMax = mMax * slot // still show a ceiling for UI tiers
+			sum += model.publishedPrice(d, pMin, pMax)
+		}
+		return float64(roster) * sum / float64(len(drvs))
+	}
+	solution := func(t float64, binding string, iter int) F1BandSolutionV2 {
+		pMin, pMax := band(t)
+		achieved := lineup(t)
+		return F1BandSolutionV2{
+			PMin: pMin, PMax: pMax,
+			TargetLineup: target, AchievedLineup: achieved,
+			Slack: target - achieved, Binding: binding, Iterations: iter,
 		}
 	}
 
-	return
+	if len(drvs) == 0 {
+		return solution(0, "none", 0)
+	}
+	// target out of reach ⇒ sit on the constraint that binds
+	if lineup(-1) > target+cfg.Tolerance {
+		return solution(-1, "floor", 0)
+	}
+	if lineup(1) < target-cfg.Tolerance {
+		return solution(1, "ceiling", 0)
+	}
+
+	// bisection on the monotone lineup(t)
+	a, b := -1.0, 1.0
+	best, bestGap := 0.0, math.Inf(1)
+	for i := 1; i <= cfg.MaxIter; i++ {
+		t := (a + b) / 2
+		got := lineup(t)
+		if gap := math.Abs(got - target); gap < bestGap {
+			best, bestGap = t, gap
+		}
+		if math.Abs(got-target) <= cfg.Tolerance {
+			return solution(t, "none", i)
+		}
+		if got < target {
+			a = t
+		} else {
+			b = t
+		}
+	}
+	// charm rounding made the target unreachable within tolerance
+	return solution(best, "rounding", cfg.MaxIter)
+}
+
+// PrintBandSolution prints the last solved budget band.
+func (model *F1QuantumPricingModelV2) PrintBandSolution() {
+	b := model.LastBand
+	fmt.Println("\n=== BUDGET BAND ===")
+	fmt.Println(strings.Repeat("-", 50))
+	fmt.Printf("%-22s $%.2fM – $%.2fM\n", "Band", b.PMin, b.PMax)
+	fmt.Printf("%-22s %s\n", "Price curve", model.curve().Name())
+	fmt.Printf("%-22s $%.2fM\n", "Target lineup", b.TargetLineup)
+	fmt.Printf("%-22s $%.2fM\n", "Achieved lineup", b.AchievedLineup)
+	fmt.Printf("%-22s $%+.2fM\n", "Slack", b.Slack)
+	fmt.Printf("%-22s %s (%d iterations)\n", "Binding constraint", b.Binding, b.Iterations)
+	fmt.Println(strings.Repeat("-", 50))
 }
 
-// price charm: nearest 0.1 M then replace .0 ⇒ .9 and .6 ⇒ .4
-func charm(x float64) float64 {
-	return math.Ceil(x*2) / 2
+// price charm: presentation rounding through the configured strategy
+// (default "half-up": next 0.5 M above the price, see price_rounding.go)
+func (model *F1QuantumPricingModelV2) charm(d *F1CompleteDriverV2, x float64) float64 {
+	return model.Rounding.Round(x, PriceRoundingContext{
+		Driver: d.BasicData.Name,
+		Team:   d.BasicData.TeamData.Name,
+		Tier:   d.BasicData.TeamData.BudgetTier,
+	})
+}
+
+// pricingRound is the round the price sheet is published for.
+func (model *F1QuantumPricingModelV2) pricingRound(drvs []*F1CompleteDriverV2) int {
+	if model.Round > 0 {
+		return model.Round
+	}
+	last := 0
+	for _, d := range drvs {
+		if d.BasicData.TeamData.CurrentRace > last {
+			last = d.BasicData.TeamData.CurrentRace
+		}
+	}
+	return last + 1
 }
 
-func (model *F1QuantumPricingModelV2) PriceDrivers(drvs []*F1CompleteDriverV2, cap float64, roster int) {
+func (model *F1QuantumPricingModelV2) PriceDrivers(drvs []*F1CompleteDriverV2, cap float64, roster int) []F1DriverPriceV2 {
 	// 1) RAW + Strength
 	score := make([]float64, 0, len(drvs))
 	for _, d := range drvs {
-		d.RawScore = rawScore(d)
+		d.RawScore = model.Weights.rawScore(d)
 		d.Strength = logistic(d.RawScore)
 		score = append(score, d.Strength)
 	}
@@ -1177,26 +1562,162 @@ func (model *F1QuantumPricingModelV2) PriceDrivers(drvs []*F1CompleteDriverV2, c
 		d.ScaledStrength = (d.Strength - min) / (max - min)
 	}
 
-	// 2) dynamic band
-	pMin, pMax := solveBand(drvs, cap, roster)
+	// 2) price curve + dynamic band (ownership demand moves included)
+	round := model.pricingRound(drvs)
+	model.attachDemand(drvs, round)
+	scaled := make([]float64, len(drvs))
+	for i, d := range drvs {
+		scaled[i] = d.ScaledStrength
+	}
+	model.curve().Prepare(scaled)
+	band := model.solveBand(drvs, nil, cap, roster)
+
+	// 2b) editorial overrides: fix those prices, re-solve the band for the rest
+	editorial := model.activeEditorialOverrides(drvs, round)
+	forced := make(map[*F1CompleteDriverV2]float64, len(editorial))
+	if len(editorial) > 0 {
+		for _, d := range drvs {
+			if o, ok := editorial[d.BasicData.Name]; ok {
+				forced[d] = o.price(model.basePrice(d, band.PMin, band.PMax))
+			}
+		}
+		band = model.solveBand(drvs, forced, cap, roster)
+	}
+	model.LastBand = band
+	pMin, pMax := band.PMin, band.PMax
 
 	// 3) base & elastic price
-	for _, d := range drvs {
-		base := pMin + (pMax-pMin)*d.ScaledStrength // linear interpolation
-		base = charm(base)                          // psychological rounding
+	modelPrices := make([]float64, len(drvs))
+	for i, d := range drvs {
+		modelPrices[i] = model.publishedPrice(d, pMin, pMax)
+	}
 
-		// elasticity – steeper if unreliable or volatile
-		elast := 0.45 + 0.25*(1-d.ConsRaw) +
-			0.10*math.Max(0, d.DNAvarZ) +
-			0.10*math.Max(0, d.VOLz)
+	// 4) business rules / editorial overrides
+	finals := make([]float64, len(drvs))
+	editorialAdjs := make([]float64, len(drvs))
+	ruleLogs := make([][]PriceRuleLogEntry, len(drvs))
+	inputs := make([]PriceRuleInput, len(drvs))
+	for i, d := range drvs {
+		if fp, ok := forced[d]; ok {
+			// editorial decisions are final: no elasticity, no business rules
+			finals[i], editorialAdjs[i] = fp, fp-modelPrices[i]
+			o := editorial[d.BasicData.Name]
+			ruleLogs[i] = []PriceRuleLogEntry{editorialLogEntry(o, d.BasicData.Name, round, modelPrices[i], fp)}
+			continue
+		}
+		inputs[i] = PriceRuleInput{
+			Driver:   d.BasicData.Name,
+			Tier:     d.BasicData.TeamData.BudgetTier,
+			Round:    round,
+			Previous: d.Price,
+			Proposed: modelPrices[i],
+		}
+		finals[i], ruleLogs[i] = model.Rules.Apply(inputs[i])
+	}
+
+	// 5) teammates kept apart if configured, after the rules so a cap or
+	// freeze cannot re-tie them; a nudge only lands where the rules would
+	// leave it, and editorial prices do not move
+	model.Rounding.SeparateTeammates(len(drvs),
+		func(i int) string { return drvs[i].TeamID },
+		func(i int) float64 { return drvs[i].Strength },
+		func(i int, p float64) bool {
+			if _, ok := forced[drvs[i]]; ok {
+				return false
+			}
+			in := inputs[i]
+			in.Proposed = p
+			got, _ := model.Rules.Apply(in)
+			return got == p
+		},
+		func(i int) float64 { return finals[i] },
+		func(i int, p float64) {
+			ruleLogs[i] = append(ruleLogs[i], PriceRuleLogEntry{
+				Driver: drvs[i].BasicData.Name, Round: round, Rule: "distinct-teammates",
+				Before: finals[i], After: p, Note: "tied with a teammate",
+			})
+			finals[i] = p
+		})
+
+	// the band reported spend before rules and separation; report what ships
+	var spend float64
+	for _, p := range finals {
+		spend += p
+	}
+	if len(drvs) > 0 {
+		model.LastBand.AchievedLineup = float64(roster) * spend / float64(len(drvs))
+		model.LastBand.Slack = model.LastBand.TargetLineup - model.LastBand.AchievedLineup
+	}
+
+	projections := model.ProjectPoints(drvs)
+	out := make([]F1DriverPriceV2, 0, len(drvs))
+	for i, d := range drvs {
+		base := model.basePrice(d, pMin, pMax)
+		elast := model.elasticity(d)
+		prev := d.Price
+		modelPrice := modelPrices[i]
+		final, editorialAdj, ruleLog := finals[i], editorialAdjs[i], ruleLogs[i]
+		d.Price = final
+
+		out = append(out, F1DriverPriceV2{
+			Driver: *d,
+			Price:  final,
+			ComponentBreakdown: map[string]float64{
+				"Raw Score":          d.RawScore,
+				"Popularity":         model.Weights.POP * d.PopZ,
+				"Age Curve":          model.Weights.AGE * d.AgeZ,
+				"Team Leader":        model.Weights.LEAD * d.LeadZ,
+				"Strength":           d.Strength,
+				"Band Min":           pMin,
+				"Band Max":           pMax,
+				"Curve Position":     model.curve().Position(d.ScaledStrength),
+				"Base Price":         base,
+				"Elasticity":         elast,
+				"Previous Price":     prev,
+				"Demand Adjustment":  d.Demand.Adjustment,
+				"Model Price":        modelPrice,
+				"Editorial Override": editorialAdj,
+				"Rule Adjustment":    final - modelPrice - editorialAdj,
+				"Final Price":        final,
+			},
+			RuleLog:    ruleLog,
+			Projection: projections[i].withPrice(final),
+		})
+	}
+	return out
+}
 
-		if d.Price == 0 { // first call ever
-			d.Price = base
-		} else {
-			d.Price += elast * (base - d.Price) // move toward base
+// PrintDriverPrices prints the v2 price sheet grouped by team.
+func (model *F1QuantumPricingModelV2) PrintDriverPrices(prices []F1DriverPriceV2) {
+	fmt.Println("\n=== F1 FANTASY DRIVER PRICES (V2) ===")
+	fmt.Printf("Price curve: %s   Rounding: %s\n", model.curve().Name(), model.Rounding.Name())
+	fmt.Println(strings.Repeat("-", 80))
+	fmt.Printf("%-20s %-16s %-10s %-10s %-10s %s\n",
+		"DRIVER", "TEAM", "PRICE", "PREVIOUS", "STRENGTH", "RULES")
+	fmt.Println(strings.Repeat("-", 80))
+	sorted := append([]F1DriverPriceV2(nil), prices...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Price > sorted[j].Price })
+	for _, p := range sorted {
+		rules := make([]string, 0, len(p.RuleLog))
+		for _, e := range p.RuleLog {
+			rules = append(rules, e.Rule)
 		}
-		fmt.Println(d.BasicData.Name)
-		fmt.Println(d.BasicData.TeamData.Name)
-		fmt.Println(d.Price)
+		fmt.Printf("%-20s %-16s %-10s %-10s %-10.3f %s\n",
+			p.Driver.BasicData.Name,
+			p.Driver.BasicData.TeamData.Name,
+			fmt.Sprintf("$%.1fM", p.Price),
+			fmt.Sprintf("$%.1fM", p.ComponentBreakdown["Previous Price"]),
+			p.Driver.Strength,
+			strings.Join(rules, ","))
+	}
+	fmt.Println(strings.Repeat("-", 80))
+}
+
+// RuleLogV2 flattens the rule log of a v2 price sheet.
+func RuleLogV2(prices []F1DriverPriceV2) []PriceRuleLogEntry {
+	var out []PriceRuleLogEntry
+	for _, p := range prices {
+		out = append(out, p.RuleLog...)
 	}
+	return out
 }
@@ -0,0 +1,246 @@
+package pricingservice
+
+import (
+	"math"
+	"testing"
+)
+
+const eps = 1e-9
+
+func approx(a, b float64) bool { return math.Abs(a-b) < eps }
+
+func race(n, grid, finish int, pts float64, classified bool) F1RaceResultV2 {
+	return F1RaceResultV2{RaceNumber: n, StartPosition: grid, FinishPosition: finish, PointsScored: pts, Classified: classified}
+}
+
+func TestWindowNewestFiveClassified(t *testing.T) {
+	s := F1BasicSeasonStatsV2{RecentRaces: []F1RaceResultV2{
+		race(1, 1, 1, 25, true),
+		race(7, 1, 0, 0, false),
+		race(3, 1, 3, 15, true),
+		race(6, 1, 6, 8, true),
+		race(2, 1, 2, 18, true),
+		race(5, 1, 5, 10, true),
+		race(4, 1, 4, 12, true),
+	}}
+	w := s.window()
+	want := []int{6, 5, 4, 3, 2}
+	if len(w) != len(want) {
+		t.Fatalf("window has %d rows, want %d", len(w), len(want))
+	}
+	for i, n := range want {
+		if w[i].RaceNumber != n {
+			t.Errorf("window[%d] = round %d, want %d", i, w[i].RaceNumber, n)
+		}
+	}
+	if s.Rows() != 5 {
+		t.Errorf("Rows() = %d, want 5", s.Rows())
+	}
+}
+
+func TestRecRawEWMA(t *testing.T) {
+	tests := []struct {
+		name  string
+		races []F1RaceResultV2
+		want  float64
+	}{
+		{"empty", nil, 0},
+		{"single", []F1RaceResultV2{race(1, 1, 1, 25, true)}, 0.35 * 25},
+		{"newest first", []F1RaceResultV2{race(1, 1, 2, 18, true), race(2, 1, 1, 25, true)},
+			0.35*25 + 0.65*0.35*18},
+		{"unclassified skipped", []F1RaceResultV2{race(1, 1, 2, 18, true), race(2, 1, 0, 0, false)},
+			0.35 * 18},
+	}
+	for _, tt := range tests {
+		s := F1BasicSeasonStatsV2{RecentRaces: tt.races}
+		if got := s.RecRaw(); !approx(got, tt.want) {
+			t.Errorf("%s: RecRaw() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestGainRaw(t *testing.T) {
+	s := F1BasicSeasonStatsV2{RecentRaces: []F1RaceResultV2{
+		race(1, 10, 5, 10, true), // +5
+		race(2, 4, 6, 8, true),   // −2
+		race(3, 20, 0, 0, false), // outside the window
+	}}
+	if got := s.GainRaw(0); !approx(got, 1.5) {
+		t.Errorf("GainRaw(0) = %v, want 1.5", got)
+	}
+	// a car that usually drops two places is expected to finish 12th and 6th
+	if got := s.GainRaw(2); !approx(got, 3.5) {
+		t.Errorf("GainRaw(2) = %v, want 3.5", got)
+	}
+
+	// a penalty start is measured from the pace grid, not the back of the grid
+	pen := F1BasicSeasonStatsV2{RecentRaces: []F1RaceResultV2{
+		{RaceNumber: 1, StartPosition: 18, GridPenaltyPlaces: 10, FinishPosition: 6, Classified: true},
+		{RaceNumber: 2, QualifyingPosition: 3, StartPosition: 13, FinishPosition: 5, Classified: true},
+	}}
+	if got := pen.GainRaw(0); !approx(got, 0) {
+		t.Errorf("GainRaw with grid penalties = %v, want 0", got)
+	}
+	if got := (&F1BasicSeasonStatsV2{}).GainRaw(0); got != 0 {
+		t.Errorf("GainRaw on empty season = %v, want 0", got)
+	}
+}
+
+func TestClutchRaw(t *testing.T) {
+	s := F1BasicSeasonStatsV2{RecentRaces: []F1RaceResultV2{
+		race(1, 1, 1, 25, true),
+		race(2, 1, 5, 10, true),
+		race(3, 1, 6, 8, true),
+		race(4, 1, 12, 0, true),
+		race(5, 1, 0, 0, false),
+	}}
+	if got := s.ClutchRaw(); !approx(got, 0.5) {
+		t.Errorf("ClutchRaw() = %v, want 0.5", got)
+	}
+	if got := (&F1BasicSeasonStatsV2{}).ClutchRaw(); got != 0 {
+		t.Errorf("ClutchRaw on empty season = %v, want 0", got)
+	}
+}
+
+func TestSeasonDecayWeight(t *testing.T) {
+	decay := NewF1SeasonDecayV2()
+	want := []float64{0.60, 0.36, 0.216, 0.1296}
+	for k, w := range want {
+		if got := decay.Weight(k); !approx(got, w) {
+			t.Errorf("Weight(%d) = %v, want %v", k, got, w)
+		}
+	}
+}
+
+func TestCompute3y(t *testing.T) {
+	d := &F1CompleteDriverV2{BasicData: F1BasicDriverDataV2{Seasons: []F1BasicSeasonStatsV2{
+		{Year: 2023, Races: 10, Points: 50, Wins: 0, TeamPoints: 100, TeamPosition: 10},
+		{Year: 2025, Races: 10, Points: 200, Wins: 5, DNFs: 1, TeamPoints: 300, TeamPosition: 1},
+		{Year: 2024, Races: 10, Points: 100, Wins: 1, TeamPoints: 250, TeamPosition: 4},
+		{Year: 2022, Races: 10, Points: 999, Wins: 10, TeamPoints: 999, TeamPosition: 1}, // fourth season: ignored
+	}}}
+	a := d.compute3y(10, NewF1SeasonDecayV2(), NewTeamRegistry())
+
+	sum := 0.60 + 0.36 + 0.216
+	wantPPR := (0.60*20 + 0.36*10 + 0.216*5) / sum
+	if !approx(a.PPR, wantPPR) {
+		t.Errorf("PPR = %v, want %v", a.PPR, wantPPR)
+	}
+	wantWIN := (0.60*0.5 + 0.36*0.1) / sum
+	if !approx(a.WIN, wantWIN) {
+		t.Errorf("WIN = %v, want %v", a.WIN, wantWIN)
+	}
+	wantDNF := 0.60 * 0.1 / sum
+	if !approx(a.DNF, wantDNF) {
+		t.Errorf("DNF = %v, want %v", a.DNF, wantDNF)
+	}
+	wantCHAMP := (0.60*1 + 0.36*(1-3.0/9) + 0.216*0) / sum
+	if !approx(a.CHAMP, wantCHAMP) {
+		t.Errorf("CHAMP = %v, want %v", a.CHAMP, wantCHAMP)
+	}
+
+	if got := (&F1CompleteDriverV2{}).compute3y(10, NewF1SeasonDecayV2(), NewTeamRegistry()); got != (seasonAgg{}) {
+		t.Errorf("compute3y with no seasons = %+v, want zero", got)
+	}
+}
+
+func TestDampedZ(t *testing.T) {
+	tests := []struct {
+		name        string
+		val, mu, sd float64
+		rows        int
+		clamp       bool
+		want        float64
+	}{
+		{"full window", 3, 1, 1, 5, false, 2},
+		{"damped by rows", 3, 1, 1, 2, false, 0.8},
+		{"clamped before damping", 10, 0, 1, 5, true, 3},
+		{"unclamped", 10, 0, 1, 5, false, 10},
+		{"zero spread", 3, 1, 0, 5, true, 0},
+	}
+	for _, tt := range tests {
+		if got := dampedZ(tt.val, tt.mu, tt.sd, tt.rows, tt.clamp); !approx(got, tt.want) {
+			t.Errorf("%s: dampedZ = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func bandDrivers(n int) []*F1CompleteDriverV2 {
+	drvs := make([]*F1CompleteDriverV2, n)
+	for i := range drvs {
+		drvs[i] = &F1CompleteDriverV2{ScaledStrength: float64(i) / float64(n-1), ConsRaw: 1}
+	}
+	return drvs
+}
+
+func TestSolveBandHitsTarget(t *testing.T) {
+	model := NewF1QuantumPricingModelV2()
+	sol := model.solveBand(bandDrivers(20), nil, 50, 2)
+	if sol.Binding != "none" {
+		t.Fatalf("Binding = %q, want none", sol.Binding)
+	}
+	if math.Abs(sol.Slack) > model.Band.Tolerance {
+		t.Errorf("|Slack| = %v, want ≤ %v", math.Abs(sol.Slack), model.Band.Tolerance)
+	}
+	if !approx(sol.TargetLineup, 45) {
+		t.Errorf("TargetLineup = %v, want 45", sol.TargetLineup)
+	}
+	if sol.PMin < model.Band.MMin*25-eps || sol.PMax > model.Band.MMax*25+eps || sol.PMin > sol.PMax {
+		t.Errorf("band %v–%v outside [%v, %v]", sol.PMin, sol.PMax, model.Band.MMin*25, model.Band.MMax*25)
+	}
+}
+
+func TestSolveBandBindingConstraints(t *testing.T) {
+	model := NewF1QuantumPricingModelV2()
+
+	// everyone already at the ceiling price: even the lowest band overshoots
+	high := bandDrivers(20)
+	for _, d := range high {
+		d.Price = 40
+	}
+	if sol := model.solveBand(high, nil, 50, 2); sol.Binding != "floor" {
+		t.Errorf("over-priced grid: Binding = %q, want floor", sol.Binding)
+	}
+
+	// editorial overrides pin every price far below target
+	low := bandDrivers(20)
+	forced := make(map[*F1CompleteDriverV2]float64)
+	for _, d := range low {
+		forced[d] = 5
+	}
+	if sol := model.solveBand(low, forced, 50, 2); sol.Binding != "ceiling" {
+		t.Errorf("forced cheap grid: Binding = %q, want ceiling", sol.Binding)
+	}
+
+	if sol := model.solveBand(nil, nil, 50, 2); sol.Binding != "none" || sol.Iterations != 0 {
+		t.Errorf("empty grid: %+v, want no iterations", sol)
+	}
+}
+
+func TestCharm(t *testing.T) {
+	model := NewF1QuantumPricingModelV2()
+	d := &F1CompleteDriverV2{}
+	tests := []struct{ in, want float64 }{
+		{10.0, 10.0},
+		{10.01, 10.5},
+		{10.5, 10.5},
+		{10.51, 11.0},
+		{20.000000001, 20.0}, // float noise is not rounded up
+	}
+	for _, tt := range tests {
+		if got := model.charm(d, tt.in); !approx(got, tt.want) {
+			t.Errorf("charm(%v) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestBasePriceStaysInBand(t *testing.T) {
+	model := NewF1QuantumPricingModelV2()
+	top := &F1CompleteDriverV2{ScaledStrength: 1}
+	if got := model.basePrice(top, 14.8, 33.75); got != 33.75 {
+		t.Errorf("top base price = %v, want the 33.75 ceiling", got)
+	}
+	if got := model.basePrice(&F1CompleteDriverV2{}, 14.8, 33.75); got != 15 {
+		t.Errorf("bottom base price = %v, want 15", got)
+	}
+}
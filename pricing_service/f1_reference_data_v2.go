@@ -0,0 +1,340 @@
+package pricingservice
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// ============================================================
+//  REFERENCE DATA  (engine & budget tiers)
+// ============================================================
+//
+// The ENG and BUD terms read a 0-1 tier per power unit and per budget
+// tier label. The tiers are kept per season because they move with the
+// regulations (a new power-unit era re-orders the engines), and are
+// loaded from a JSON file:
+//
+//	{
+//	  "Seasons": [
+//	    {"Year": 2026,
+//	     "EngineTiers": {"Mercedes": 0.95, "Audi": 0.70, "Red Bull Ford": 0.80},
+//	     "BudgetTiers": {"Top": 1.0, "Upper-Mid": 0.75, "Lower-Mid": 0.5, "Backmarker": 0.25}}
+//	  ]
+//	}
+//
+// Power units are matched by their table key alone; a power unit known
+// under several names lists each of them (the built-in table carries
+// "Honda", "RBPT" and "Honda RBPT"). Team names never reach these tables:
+// they go through the team registry (team_registry.go).
+//
+// A season uses the table of the newest listed year at or before it; a
+// Year of 0 is the fallback for seasons before every listed year. The
+// built-in table (Year 0) holds the tiers the model always used.
+//
+// A power unit or budget tier missing from the table is estimated from
+// team results instead of defaulting silently: each team's strength is
+// its average championship standing (1 champion … 0 last) over the
+// current and two previous seasons, a line is fitted through the tiers
+// that are known, and the unknown entry is read off it. Every estimate or
+// default is recorded in TierWarnings.
+
+const (
+	defaultEngineTier = 0.60 // unknown power unit with no team results
+	defaultBudgetTier = 0.50 // unknown budget tier with no team results
+	tierEvidenceYears = 3    // seasons of standings behind a team's strength
+)
+
+// F1ReferenceSeasonV2 is the tier table of one season; keys are matched
+// case-insensitively.
+type F1ReferenceSeasonV2 struct {
+	Year        int                // 0 = any season before the listed ones
+	EngineTiers map[string]float64 // power unit → 0-1
+	BudgetTiers map[string]float64 // budget tier label → 0-1
+}
+
+// F1ReferenceDataV2 holds the per-season tier tables.
+type F1ReferenceDataV2 struct {
+	Seasons []F1ReferenceSeasonV2
+}
+
+func NewF1ReferenceDataV2() F1ReferenceDataV2 {
+	return F1ReferenceDataV2{
+		Seasons: []F1ReferenceSeasonV2{{
+			EngineTiers: map[string]float64{
+				"mercedes":   1.00,
+				"ferrari":    0.90,
+				"honda":      0.85,
+				"rbpt":       0.85,
+				"honda rbpt": 0.85,
+				"renault":    0.80,
+				"alpine":     0.80,
+			},
+			BudgetTiers: map[string]float64{
+				"top":        1.00,
+				"upper-mid":  0.75,
+				"lower-mid":  0.50,
+				"backmarker": 0.25,
+			},
+		}},
+	}
+}
+
+// LoadReferenceDataV2 reads a reference JSON file on top of the built-in
+// tables: a listed year replaces the built-in table of that year. Fields
+// the file format does not have (such as the old "Aliases") are rejected
+// rather than ignored.
+func LoadReferenceDataV2(path string) (F1ReferenceDataV2, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return F1ReferenceDataV2{}, fmt.Errorf("error reading reference data: %v", err)
+	}
+	var file F1ReferenceDataV2
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&file); err != nil {
+		return F1ReferenceDataV2{}, fmt.Errorf("error parsing reference data: %v", err)
+	}
+
+	ref := NewF1ReferenceDataV2()
+	seen := map[int]bool{}
+	for _, s := range file.Seasons {
+		if s.Year < 0 || seen[s.Year] {
+			return F1ReferenceDataV2{}, fmt.Errorf("reference data: invalid or repeated year %d", s.Year)
+		}
+		seen[s.Year] = true
+		season := F1ReferenceSeasonV2{Year: s.Year}
+		if season.EngineTiers, err = lowerTiers(s.EngineTiers); err != nil {
+			return F1ReferenceDataV2{}, fmt.Errorf("reference data %d engine tiers: %v", s.Year, err)
+		}
+		if season.BudgetTiers, err = lowerTiers(s.BudgetTiers); err != nil {
+			return F1ReferenceDataV2{}, fmt.Errorf("reference data %d budget tiers: %v", s.Year, err)
+		}
+		ref.Seasons = slices.DeleteFunc(ref.Seasons, func(x F1ReferenceSeasonV2) bool { return x.Year == s.Year })
+		ref.Seasons = append(ref.Seasons, season)
+	}
+	sort.Slice(ref.Seasons, func(i, j int) bool { return ref.Seasons[i].Year < ref.Seasons[j].Year })
+	return ref, nil
+}
+
+func lowerTiers(in map[string]float64) (map[string]float64, error) {
+	out := make(map[string]float64, len(in))
+	for k, v := range in {
+		if math.IsNaN(v) || v < 0 || v > 1 {
+			return nil, fmt.Errorf("tier of %q is %v, want 0-1", k, v)
+		}
+		out[strings.ToLower(strings.TrimSpace(k))] = v
+	}
+	return out, nil
+}
+
+// puKey is the table key of a power-unit name.
+func puKey(pu string) string {
+	return strings.ToLower(strings.TrimSpace(pu))
+}
+
+// Season returns the tier table in force in year.
+func (r F1ReferenceDataV2) Season(year int) F1ReferenceSeasonV2 {
+	var best F1ReferenceSeasonV2
+	found := false
+	for _, s := range r.Seasons {
+		if s.Year <= year && (!found || s.Year > best.Year) {
+			best, found = s, true
+		}
+	}
+	return best
+}
+
+// EngineTier is the table tier of a power unit in year.
+func (r F1ReferenceDataV2) EngineTier(year int, pu string) (float64, bool) {
+	v, ok := r.Season(year).EngineTiers[puKey(pu)]
+	return v, ok
+}
+
+// BudgetTier is the table value of a budget tier label in year.
+func (r F1ReferenceDataV2) BudgetTier(year int, label string) (float64, bool) {
+	v, ok := r.Season(year).BudgetTiers[strings.ToLower(strings.TrimSpace(label))]
+	return v, ok
+}
+
+// teamEvidence is each team's result strength, 1 for the champion … 0 for
+// last, averaged over the current season (once started) and the previous
+// seasons up to tierEvidenceYears.
+func teamEvidence(teams map[string]*F1TeamDataV2) map[string]float64 {
+	field := len(teams)
+	for _, t := range teams {
+		field = max(field, t.SeasonPosition)
+		for _, h := range t.SeasonHistory {
+			field = max(field, h.Position)
+		}
+	}
+	standing := func(pos int) float64 {
+		if field < 2 {
+			return 0.5
+		}
+		return float64(field-pos) / float64(field-1)
+	}
+
+	out := make(map[string]float64, len(teams))
+	for key, t := range teams {
+		var sum float64
+		n := 0
+		if t.CurrentRace > 0 && t.SeasonPosition > 0 {
+			sum += standing(t.SeasonPosition)
+			n++
+		}
+		hist := append([]F1TeamSeasonHistoryV2(nil), t.SeasonHistory...)
+		sort.SliceStable(hist, func(i, j int) bool { return hist[i].Year > hist[j].Year })
+		for _, h := range hist {
+			if n == tierEvidenceYears {
+				break
+			}
+			if h.Position > 0 {
+				sum += standing(h.Position)
+				n++
+			}
+		}
+		if n > 0 {
+			out[key] = sum / float64(n)
+		}
+	}
+	return out
+}
+
+// tierFit reads a tier off result strength: a least-squares line through
+// the known (strength, tier) pairs, or lo + (hi − lo)·strength when they
+// cannot carry one (fewer than two distinct strengths, or a line that
+// does not rise).
+func tierFit(xs, ys []float64, lo, hi float64) func(float64) float64 {
+	a, b := lo, hi-lo
+	if len(xs) >= 2 {
+		mx, _ := meanStd(xs)
+		my, _ := meanStd(ys)
+		var sxy, sxx float64
+		for i := range xs {
+			sxy += (xs[i] - mx) * (ys[i] - my)
+			sxx += (xs[i] - mx) * (xs[i] - mx)
+		}
+		if sxx > 0 && sxy > 0 {
+			b = sxy / sxx
+			a = my - b*mx
+		}
+	}
+	return func(x float64) float64 { return clamp(a+b*x, 0, 1) }
+}
+
+// attachTiers sets EngineTierRaw/BudgetTierRaw from the reference tables,
+// estimating what they lack from team results.
+func (model *F1QuantumPricingModelV2) attachTiers(drvs []*F1CompleteDriverV2, teams map[string]*F1TeamDataV2) {
+	model.TierWarnings = nil
+	warned := map[string]bool{}
+	warn := func(format string, args ...any) {
+		msg := fmt.Sprintf(format, args...)
+		if !warned[msg] {
+			warned[msg] = true
+			model.TierWarnings = append(model.TierWarnings, msg)
+		}
+	}
+
+	ref := model.Reference
+	ev := teamEvidence(teams)
+	keys := make([]string, 0, len(teams))
+	for k := range teams {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	// a power unit's strength is the mean strength of the teams it powers
+	puTeams := map[string][]float64{}
+	for _, k := range keys {
+		if e, ok := ev[k]; ok {
+			pu := puKey(teams[k].PowerUnit)
+			puTeams[pu] = append(puTeams[pu], e)
+		}
+	}
+	puEvidence := func(pu string) (float64, bool) {
+		es := puTeams[puKey(pu)]
+		if len(es) == 0 {
+			return 0, false
+		}
+		mu, _ := meanStd(es)
+		return mu, true
+	}
+
+	type fits struct{ engine, budget func(float64) float64 }
+	byYear := map[int]fits{}
+	fitFor := func(year int) fits {
+		if f, ok := byYear[year]; ok {
+			return f
+		}
+		var ex, ey, bx, by []float64
+		done := map[string]bool{}
+		for _, k := range keys {
+			t := teams[k]
+			pu := puKey(t.PowerUnit)
+			if tier, ok := ref.EngineTier(year, pu); ok && !done[pu] {
+				if e, ok := puEvidence(pu); ok {
+					ex, ey = append(ex, e), append(ey, tier)
+					done[pu] = true
+				}
+			}
+			if tier, ok := ref.BudgetTier(year, t.BudgetTier); ok {
+				if e, ok := ev[k]; ok {
+					bx, by = append(bx, e), append(by, tier)
+				}
+			}
+		}
+		f := fits{engine: tierFit(ex, ey, 0.5, 1), budget: tierFit(bx, by, 0.25, 1)}
+		byYear[year] = f
+		return f
+	}
+
+	for _, d := range drvs {
+		key := d.TeamID
+		team := teams[key]
+		year := team.Year
+		if year == 0 {
+			year = d.BasicData.SeasonYear()
+		}
+
+		if tier, ok := ref.EngineTier(year, team.PowerUnit); ok {
+			d.EngineTierRaw = tier
+		} else if e, ok := puEvidence(team.PowerUnit); ok {
+			d.EngineTierRaw = fitFor(year).engine(e)
+			warn("unknown power unit %q (%s, %d): engine tier estimated at %.2f from team results",
+				team.PowerUnit, team.Name, year, d.EngineTierRaw)
+		} else {
+			d.EngineTierRaw = defaultEngineTier
+			warn("unknown power unit %q (%s, %d) and no team results: engine tier defaults to %.2f",
+				team.PowerUnit, team.Name, year, defaultEngineTier)
+		}
+
+		if tier, ok := ref.BudgetTier(year, team.BudgetTier); ok {
+			d.BudgetTierRaw = tier
+		} else if e, ok := ev[key]; ok {
+			d.BudgetTierRaw = fitFor(year).budget(e)
+			warn("unknown budget tier %q (%s, %d): budget tier estimated at %.2f from team results",
+				team.BudgetTier, team.Name, year, d.BudgetTierRaw)
+		} else {
+			d.BudgetTierRaw = defaultBudgetTier
+			warn("unknown budget tier %q (%s, %d) and no team results: budget tier defaults to %.2f",
+				team.BudgetTier, team.Name, year, defaultBudgetTier)
+		}
+	}
+}
+
+// PrintTierWarnings lists the tiers the last run had to estimate or default.
+func PrintTierWarnings(warnings []string) {
+	if len(warnings) == 0 {
+		return
+	}
+	fmt.Println("\n=== REFERENCE DATA WARNINGS ===")
+	for _, w := range warnings {
+		fmt.Println("Warning:", w)
+	}
+}
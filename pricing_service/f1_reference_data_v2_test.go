@@ -0,0 +1,94 @@
+package pricingservice
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeReferenceJSON(t *testing.T, body string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "reference.json")
+	if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadReferenceDataV2(t *testing.T) {
+	ref, err := LoadReferenceDataV2(writeReferenceJSON(t, `{
+		"Seasons": [{"Year": 2026, "EngineTiers": {"Mercedes": 0.95, "Audi": 0.7}, "BudgetTiers": {"Top": 1}}]
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, ok := ref.EngineTier(2026, " audi"); !ok || v != 0.7 {
+		t.Errorf("2026 Audi = %v %v, want 0.7", v, ok)
+	}
+	if _, ok := ref.EngineTier(2026, "Sauber Audi"); ok {
+		t.Errorf("a team-style name matched the Audi power unit")
+	}
+	if _, ok := ref.EngineTier(2026, "Ferrari"); ok {
+		t.Errorf("2026 table fell back to the built-in Ferrari tier")
+	}
+	if v, ok := ref.EngineTier(2027, "MERCEDES"); !ok || v != 0.95 {
+		t.Errorf("2027 Mercedes = %v %v, want the 2026 table", v, ok)
+	}
+	if v, ok := ref.EngineTier(2025, "Ferrari"); !ok || v != 0.90 {
+		t.Errorf("2025 Ferrari = %v %v, want the built-in 0.90", v, ok)
+	}
+
+	for _, body := range []string{
+		`{"Seasons": [{"Year": 2026, "EngineTiers": {"Audi": 1.5}}]}`,
+		`{"Seasons": [{"Year": 2026}, {"Year": 2026}]}`,
+		`{"Aliases": {"Sauber Audi": "Audi"}}`,
+	} {
+		if _, err := LoadReferenceDataV2(writeReferenceJSON(t, body)); err == nil {
+			t.Errorf("accepted %s", body)
+		}
+	}
+}
+
+func tierTeam(name, pu, budget string, pos int) *F1TeamDataV2 {
+	return &F1TeamDataV2{Name: name, PowerUnit: pu, BudgetTier: budget, SeasonPosition: pos, CurrentRace: 5, Year: 2026}
+}
+
+func TestAttachTiersEstimatesUnknowns(t *testing.T) {
+	model := NewF1QuantumPricingModelV2()
+	teams := map[string]*F1TeamDataV2{
+		"a": tierTeam("A", "Mercedes", "Top", 1),
+		"b": tierTeam("B", "Ferrari", "Upper-Mid", 2),
+		"c": tierTeam("C", "Audi", "Factory", 3),
+		"d": tierTeam("D", "Renault", "Backmarker", 4),
+	}
+	var drvs []*F1CompleteDriverV2
+	for _, k := range []string{"a", "b", "c", "d"} {
+		drvs = append(drvs, &F1CompleteDriverV2{TeamID: k, BasicData: F1BasicDriverDataV2{TeamData: *teams[k]}})
+	}
+	model.attachTiers(drvs, teams)
+
+	if drvs[0].EngineTierRaw != 1 || drvs[3].BudgetTierRaw != 0.25 {
+		t.Errorf("known tiers = %v / %v", drvs[0].EngineTierRaw, drvs[3].BudgetTierRaw)
+	}
+	// line through the known engines (1, 1.0), (2/3, 0.9), (0, 0.8), read at P3 = 1/3
+	slope := 0.1 / (42.0 / 81)
+	want := 0.9 + slope*(1.0/3-5.0/9)
+	if c := drvs[2]; !approx(c.EngineTierRaw, want) {
+		t.Errorf("estimated Audi tier = %v, want %v", c.EngineTierRaw, want)
+	}
+	if c := drvs[2]; !(c.BudgetTierRaw > 0.25 && c.BudgetTierRaw < 0.75) {
+		t.Errorf("estimated budget tier = %v, want between its neighbours", c.BudgetTierRaw)
+	}
+	if len(model.TierWarnings) != 2 || !strings.Contains(model.TierWarnings[0], `"Audi"`) {
+		t.Errorf("warnings = %q", model.TierWarnings)
+	}
+
+	// no results to go on: documented defaults, still warned
+	lone := map[string]*F1TeamDataV2{"x": {Name: "X", PowerUnit: "Ford", BudgetTier: ""}}
+	d := &F1CompleteDriverV2{TeamID: "x", BasicData: F1BasicDriverDataV2{TeamData: *lone["x"]}}
+	model.attachTiers([]*F1CompleteDriverV2{d}, lone)
+	if d.EngineTierRaw != defaultEngineTier || d.BudgetTierRaw != defaultBudgetTier || len(model.TierWarnings) != 2 {
+		t.Errorf("defaults = %v / %v, warnings %q", d.EngineTierRaw, d.BudgetTierRaw, model.TierWarnings)
+	}
+}
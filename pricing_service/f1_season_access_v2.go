@@ -0,0 +1,81 @@
+package pricingservice
+
+// ============================================================
+//  SEASON ACCESS  (which season row is "current")
+// ============================================================
+//
+// Seasons arrive in whatever order the JSON lists them. Metrics never index
+// into Seasons directly; they go through the accessors below, which read
+// the season context year and leave the caller's slices untouched:
+//
+//	SeasonYear       the season being priced: TeamData.Year when the team
+//	                 snapshot carries one (the model's SeasonYear is copied
+//	                 there by NewDriver), otherwise the newest row's Year
+//	CurrentSeason    that season's row, stints merged (live metrics)
+//	PriorSeasons     earlier seasons, newest first (3-year roll-ups)
+//
+// A driver with no row for the season being priced has no current season:
+// live metrics stay at zero instead of silently reading an older year.
+
+// SeasonYear is the season the driver is being priced for (0 = no seasons).
+func (b *F1BasicDriverDataV2) SeasonYear() int {
+	if b.TeamData.Year > 0 {
+		return b.TeamData.Year
+	}
+	year := 0
+	for _, s := range b.Seasons {
+		year = max(year, s.Year)
+	}
+	return year
+}
+
+// CurrentSeason returns the row for year (0 = SeasonYear) with any
+// mid-season stints merged; ok is false if the driver has no such row.
+func (b *F1BasicDriverDataV2) CurrentSeason(year int) (F1BasicSeasonStatsV2, bool) {
+	if year == 0 {
+		year = b.SeasonYear()
+	}
+	stints := b.seasonStints(year)
+	if len(stints) == 0 {
+		return F1BasicSeasonStatsV2{}, false
+	}
+	return mergeStints(stints), true
+}
+
+// PriorSeasons returns up to n seasons before SeasonYear, newest first,
+// stints merged.
+func (b *F1BasicDriverDataV2) PriorSeasons(n int) []F1BasicSeasonStatsV2 {
+	byYear := stintsByYear(b.Seasons)
+	cur := b.SeasonYear()
+	var out []F1BasicSeasonStatsV2
+	for _, y := range yearsNewestFirst(byYear) {
+		if len(out) == n {
+			break
+		}
+		if y < cur {
+			out = append(out, mergeStints(byYear[y]))
+		}
+	}
+	return out
+}
+
+// seasonStints returns the stint rows of one season, earliest stint first.
+func (b *F1BasicDriverDataV2) seasonStints(year int) []F1BasicSeasonStatsV2 {
+	return stintsByYear(b.Seasons)[year]
+}
+
+// seasonYears lists the years up to and including SeasonYear that the
+// driver has rows for, newest first, at most n (n < 0 = all).
+func (b *F1BasicDriverDataV2) seasonYears(n int) []int {
+	cur := b.SeasonYear()
+	var out []int
+	for _, y := range yearsNewestFirst(stintsByYear(b.Seasons)) {
+		if len(out) == n {
+			break
+		}
+		if y <= cur {
+			out = append(out, y)
+		}
+	}
+	return out
+}
@@ -0,0 +1,116 @@
+package pricingservice
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func seasonRows() []F1BasicSeasonStatsV2 {
+	return []F1BasicSeasonStatsV2{
+		{Year: 2023, Team: "Alpine", Races: 22, Points: 40, RecentRaces: []F1RaceResultV2{
+			race(21, 12, 14, 0, true), race(22, 15, 16, 0, true),
+		}},
+		{Year: 2025, Team: "Williams", Races: 3, Points: 37, RecentRaces: []F1RaceResultV2{
+			race(2, 6, 3, 15, true), race(1, 4, 2, 18, true), race(3, 9, 8, 4, true),
+		}},
+		{Year: 2024, Team: "Alpine", Races: 24, Points: 70, RecentRaces: []F1RaceResultV2{
+			race(23, 8, 9, 2, true), race(24, 10, 10, 1, true),
+		}},
+	}
+}
+
+func TestLiveMetricsIgnoreSeasonOrder(t *testing.T) {
+	rows := seasonRows()
+	orders := [][]int{{0, 1, 2}, {1, 2, 0}, {2, 0, 1}, {2, 1, 0}}
+
+	var want *F1CompleteDriverV2
+	for _, order := range orders {
+		var seasons []F1BasicSeasonStatsV2
+		for _, i := range order {
+			seasons = append(seasons, rows[i])
+		}
+		d := &F1CompleteDriverV2{BasicData: F1BasicDriverDataV2{Seasons: seasons}}
+		d.attachLiveRaw()
+
+		cur, ok := d.BasicData.CurrentSeason(0)
+		if !ok || cur.Year != 2025 {
+			t.Fatalf("order %v: current season = %d (ok %v), want 2025", order, cur.Year, ok)
+		}
+		if want == nil {
+			want = d
+			// 2025 classified finishes, newest first: 8, 3, 2
+			rec := 0.35*4 + 0.65*0.35*15 + 0.65*0.65*0.35*18
+			if !approx(d.RecRaw, rec) || d.Rows != 3 {
+				t.Fatalf("RecRaw = %v over %d rows, want %v over 3", d.RecRaw, d.Rows, rec)
+			}
+			continue
+		}
+		if d.RecRaw != want.RecRaw || d.GainRaw != want.GainRaw || d.ClutchRaw != want.ClutchRaw || d.Rows != want.Rows {
+			t.Errorf("order %v: live raws differ from order %v", order, orders[0])
+		}
+	}
+}
+
+func TestSeasonAccessDoesNotMutateInput(t *testing.T) {
+	model := NewF1QuantumPricingModelV2()
+	seasons := seasonRows()
+	before := make([]F1BasicSeasonStatsV2, len(seasons))
+	for i, s := range seasons {
+		before[i] = s
+		before[i].RecentRaces = slices.Clone(s.RecentRaces)
+	}
+
+	drvs := model.NewDriverSet([]F1BasicDriverDataV2{
+		{Name: "A", Team: "Williams", Seasons: seasons, TeamData: F1TeamDataV2{Name: "Williams", CurrentRace: 3}},
+		{Name: "B", Team: "Williams", Seasons: seasonRows(), TeamData: F1TeamDataV2{Name: "Williams", CurrentRace: 3}},
+	})
+	teams := model.BuildTeamMapFromDrivers(drvs)
+	if err := model.PopulateDriverStats(drvs, teams); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(drvs[0].BasicData.Seasons, before) {
+		t.Errorf("PopulateDriverStats reordered the driver's season rows or races")
+	}
+}
+
+func TestSeasonContextYear(t *testing.T) {
+	b := F1BasicDriverDataV2{Seasons: seasonRows(), TeamData: F1TeamDataV2{Year: 2024}}
+	if got := b.SeasonYear(); got != 2024 {
+		t.Fatalf("SeasonYear() = %d, want 2024", got)
+	}
+	cur, ok := b.CurrentSeason(0)
+	if !ok || cur.Points != 70 {
+		t.Errorf("CurrentSeason(0) = %v pts (ok %v), want the 2024 row", cur.Points, ok)
+	}
+	prior := b.PriorSeasons(3)
+	if len(prior) != 1 || prior[0].Year != 2023 {
+		t.Errorf("PriorSeasons(3) = %d rows, want only 2023", len(prior))
+	}
+
+	d := &F1CompleteDriverV2{BasicData: b}
+	a := d.compute3y(10, NewF1SeasonDecayV2(), NewTeamRegistry())
+	wantPPR := (0.60*70.0/24 + 0.36*40.0/22) / (0.60 + 0.36)
+	if !approx(a.PPR, wantPPR) {
+		t.Errorf("3y PPR in 2024 context = %v, want %v (2025 excluded)", a.PPR, wantPPR)
+	}
+
+	// no row for the season being priced ⇒ no current season
+	b.TeamData.Year = 2026
+	if _, ok := b.CurrentSeason(0); ok {
+		t.Errorf("CurrentSeason(0) found a row for 2026")
+	}
+	if got := len(b.PriorSeasons(2)); got != 2 {
+		t.Errorf("PriorSeasons(2) in 2026 = %d rows, want 2", got)
+	}
+}
+
+func TestModelSeasonYearStampsTeamData(t *testing.T) {
+	model := NewF1QuantumPricingModelV2()
+	model.SeasonYear = 2024
+	d := model.NewDriver(F1BasicDriverDataV2{Seasons: seasonRows()})
+	if cur, ok := d.BasicData.CurrentSeason(0); !ok || cur.Year != 2024 {
+		t.Errorf("current season = %d (ok %v), want 2024", cur.Year, ok)
+	}
+}
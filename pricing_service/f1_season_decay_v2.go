@@ -0,0 +1,90 @@
+package pricingservice
+
+import "math"
+
+// ============================================================
+//  MULTI-SEASON DECAY  (weights of the 3-year roll-ups)
+// ============================================================
+//
+// The k-th season back (k = 0 is the season being priced) has weight
+//
+//	w_k = Rate^(k+1)                    Rate 0.6 ⇒ 0.60, 0.36, 0.216, …
+//
+// over the newest LookBack seasons the driver has rows for. With
+// PartialSeason the current season only counts for the share of the
+// calendar already raced (CurrentRace ÷ TotalRaces of the team snapshot),
+// so at round 2 last season still carries the roll-ups and by the final
+// round the current season has its full weight.
+//
+// Team-dependent metrics (SHARE, DELTA, CHAMP) describe the car as much as
+// the driver: a past season spent with another team is carried over at
+// TeamChangeCarry × its weight for those three, and at full weight for
+// the rest.
+
+const (
+	defaultSeasonDecayRate = 0.60
+	defaultSeasonLookBack  = 3
+	defaultTeamChangeCarry = 0.50
+)
+
+// F1SeasonDecayV2 configures how past seasons are weighted.
+type F1SeasonDecayV2 struct {
+	Rate            float64 // weight of a full season k back = Rate^(k+1)
+	LookBack        int     // seasons in the roll-up, current one included
+	PartialSeason   bool    // scale the current season by races completed
+	TeamChangeCarry float64 // SHARE/DELTA/CHAMP weight factor for a season with another team (1 = full)
+}
+
+func NewF1SeasonDecayV2() F1SeasonDecayV2 {
+	return F1SeasonDecayV2{
+		Rate:            defaultSeasonDecayRate,
+		LookBack:        defaultSeasonLookBack,
+		PartialSeason:   true,
+		TeamChangeCarry: defaultTeamChangeCarry,
+	}
+}
+
+// Weight is the weight of a full season k seasons back.
+func (c F1SeasonDecayV2) Weight(k int) float64 {
+	return math.Pow(c.Rate, float64(k+1))
+}
+
+// seasonProgress is the share of the current season already raced (0
+// before its first race, 1 when the team snapshot does not say).
+func seasonProgress(t *F1TeamDataV2) float64 {
+	if t.TotalRaces <= 0 || t.CurrentRace < 0 {
+		return 1
+	}
+	return clamp(float64(t.CurrentRace)/float64(t.TotalRaces), 0, 1)
+}
+
+// seasonWeights returns the overall and team-metric weights of one season
+// k back of driver d; current is true for the season being priced.
+func (c F1SeasonDecayV2) seasonWeights(d *F1CompleteDriverV2, teams *TeamRegistry, stints []F1BasicSeasonStatsV2, k int, current bool) (w, teamW float64) {
+	w = c.Weight(k)
+	if current {
+		if c.PartialSeason {
+			w *= seasonProgress(&d.BasicData.TeamData)
+		}
+		return w, w
+	}
+	if otherTeam(stints, d.TeamID, teams) {
+		return w, w * c.TeamChangeCarry
+	}
+	return w, w
+}
+
+// otherTeam reports whether a season was spent entirely with a team other
+// than the one with registry ID teamID (false when either is unknown).
+func otherTeam(stints []F1BasicSeasonStatsV2, teamID string, teams *TeamRegistry) bool {
+	if teamID == "" {
+		return false
+	}
+	for _, s := range stints {
+		id, err := teams.Resolve(s.Team)
+		if err != nil || id == teamID {
+			return false
+		}
+	}
+	return len(stints) > 0
+}
@@ -0,0 +1,76 @@
+package pricingservice
+
+import "testing"
+
+func decayDriver(current, total int) *F1CompleteDriverV2 {
+	return &F1CompleteDriverV2{TeamID: "williams", BasicData: F1BasicDriverDataV2{
+		TeamData: F1TeamDataV2{Name: "Williams", CurrentRace: current, TotalRaces: total},
+		Seasons: []F1BasicSeasonStatsV2{
+			{Year: 2025, Team: "Williams", Races: 12, Points: 120, TeamPoints: 200, TeamPosition: 2},
+			{Year: 2024, Team: "Ferrari", Races: 24, Points: 240, TeamPoints: 300, TeamPosition: 1},
+			{Year: 2023, Team: "Williams", Races: 22, Points: 22, TeamPoints: 44, TeamPosition: 9},
+			{Year: 2022, Team: "Williams", Races: 22, Points: 0, TeamPoints: 10, TeamPosition: 10},
+		},
+	}}
+}
+
+func TestDecayPartialSeason(t *testing.T) {
+	decay := NewF1SeasonDecayV2()
+	decay.TeamChangeCarry = 1
+
+	// 6 of 24 rounds raced: the current season counts a quarter
+	a := decayDriver(6, 24).compute3y(10, decay, NewTeamRegistry())
+	w := []float64{0.60 * 0.25, 0.36, 0.216}
+	want := (w[0]*10 + w[1]*10 + w[2]*1) / (w[0] + w[1] + w[2])
+	if !approx(a.PPR, want) {
+		t.Errorf("PPR at round 6 = %v, want %v", a.PPR, want)
+	}
+
+	// a finished season counts in full
+	a = decayDriver(24, 24).compute3y(10, decay, NewTeamRegistry())
+	want = (0.60*10 + 0.36*10 + 0.216*1) / (0.60 + 0.36 + 0.216)
+	if !approx(a.PPR, want) {
+		t.Errorf("PPR at round 24 = %v, want %v", a.PPR, want)
+	}
+
+	// before the first race the current season carries no weight
+	a = decayDriver(0, 24).compute3y(10, decay, NewTeamRegistry())
+	want0 := (0.36*10 + 0.216*1) / (0.36 + 0.216)
+	if !approx(a.PPR, want0) {
+		t.Errorf("PPR before round 1 = %v, want %v", a.PPR, want0)
+	}
+
+	decay.PartialSeason = false
+	if got := decayDriver(6, 24).compute3y(10, decay, NewTeamRegistry()); !approx(got.PPR, want) {
+		t.Errorf("PPR without PartialSeason = %v, want %v", got.PPR, want)
+	}
+}
+
+func TestDecayLookBack(t *testing.T) {
+	decay := NewF1SeasonDecayV2()
+	decay.TeamChangeCarry, decay.PartialSeason = 1, false
+	decay.LookBack = 4
+	a := decayDriver(24, 24).compute3y(10, decay, NewTeamRegistry())
+	want := (0.60*10 + 0.36*10 + 0.216*1 + 0.1296*0) / (0.60 + 0.36 + 0.216 + 0.1296)
+	if !approx(a.PPR, want) {
+		t.Errorf("PPR with LookBack 4 = %v, want %v", a.PPR, want)
+	}
+}
+
+func TestDecayTeamChangeCarry(t *testing.T) {
+	decay := NewF1SeasonDecayV2()
+	decay.PartialSeason = false
+	decay.TeamChangeCarry = 0.5
+	a := decayDriver(24, 24).compute3y(10, decay, NewTeamRegistry())
+
+	// the Ferrari season is halved for team-dependent metrics only
+	teamW := []float64{0.60, 0.36 * 0.5, 0.216}
+	wantShare := (teamW[0]*0.6 + teamW[1]*0.8 + teamW[2]*0.5) / (teamW[0] + teamW[1] + teamW[2])
+	if !approx(a.SHARE, wantShare) {
+		t.Errorf("SHARE = %v, want %v", a.SHARE, wantShare)
+	}
+	wantPPR := (0.60*10 + 0.36*10 + 0.216*1) / (0.60 + 0.36 + 0.216)
+	if !approx(a.PPR, wantPPR) {
+		t.Errorf("PPR = %v, want %v (not team-dependent)", a.PPR, wantPPR)
+	}
+}
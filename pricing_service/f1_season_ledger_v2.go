@@ -0,0 +1,126 @@
+package pricingservice
+
+import "strings"
+
+// ============================================================
+//  SEASON LEDGER  (in-place bookkeeping of live-season results)
+// ============================================================
+//
+// The ledger adds and removes single results from the drivers' season rows
+// and the shared team snapshots, keeping every derived count (points,
+// wins, podiums, DNFs, team and teammate points) consistent. It is used by
+// the live race-weekend state and by steward amendments.
+
+type f1SeasonLedgerV2 struct {
+	drvs   []*F1CompleteDriverV2
+	byName map[string]*F1CompleteDriverV2
+	teams  map[string]*F1TeamDataV2
+	year   int // season the results belong to
+}
+
+func newF1SeasonLedgerV2(drvs []*F1CompleteDriverV2, teams map[string]*F1TeamDataV2) f1SeasonLedgerV2 {
+	l := f1SeasonLedgerV2{drvs: drvs, teams: teams, byName: make(map[string]*F1CompleteDriverV2, len(drvs))}
+	for _, d := range drvs {
+		l.byName[strings.ToLower(d.BasicData.Name)] = d
+		l.year = max(l.year, d.BasicData.SeasonYear())
+	}
+	return l
+}
+
+// driver looks a driver up by name (case-insensitive).
+func (l *f1SeasonLedgerV2) driver(name string) (*F1CompleteDriverV2, bool) {
+	d, ok := l.byName[strings.ToLower(name)]
+	return d, ok
+}
+
+func (l *f1SeasonLedgerV2) teamOf(d *F1CompleteDriverV2) *F1TeamDataV2 {
+	return l.teams[d.TeamID]
+}
+
+// teammates returns every driver sharing d's car, d included.
+func (l *f1SeasonLedgerV2) teammates(d *F1CompleteDriverV2) []*F1CompleteDriverV2 {
+	var out []*F1CompleteDriverV2
+	for _, o := range l.drvs {
+		if o.TeamID == d.TeamID {
+			out = append(out, o)
+		}
+	}
+	return out
+}
+
+// seasonRow returns the season row (stint) a round of the live season is
+// recorded in, adding one for a driver with no row for the season yet.
+func (l *f1SeasonLedgerV2) seasonRow(d *F1CompleteDriverV2, round int) *F1BasicSeasonStatsV2 {
+	b := &d.BasicData
+	var row *F1BasicSeasonStatsV2
+	for i := range b.Seasons {
+		s := &b.Seasons[i]
+		if s.Year != l.year || round < s.FromRound || (s.ToRound != 0 && round > s.ToRound) {
+			continue
+		}
+		if row == nil || s.FromRound >= row.FromRound {
+			row = s
+		}
+	}
+	if row == nil {
+		b.Seasons = append(b.Seasons, F1BasicSeasonStatsV2{Year: l.year, Team: b.Team})
+		row = &b.Seasons[len(b.Seasons)-1]
+	}
+	return row
+}
+
+// raceResult finds a driver's recorded race result for a round.
+func (l *f1SeasonLedgerV2) raceResult(d *F1CompleteDriverV2, round int) *F1RaceResultV2 {
+	for i := range d.BasicData.Seasons {
+		s := &d.BasicData.Seasons[i]
+		if s.Year != l.year {
+			continue
+		}
+		for j := range s.RecentRaces {
+			if s.RecentRaces[j].RaceNumber == round {
+				return &s.RecentRaces[j]
+			}
+		}
+	}
+	return nil
+}
+
+// tally adds (sign +1) or removes (sign -1) one result's contribution to the
+// driver's season row, their teammates' team context and the team snapshot.
+// Sprint results only carry points.
+func (l *f1SeasonLedgerV2) tally(d *F1CompleteDriverV2, rr F1RaceResultV2, sign int, race bool) {
+	pts := float64(sign) * rr.PointsScored
+	s := l.seasonRow(d, rr.RaceNumber)
+	s.Points += pts
+	for _, o := range l.teammates(d) {
+		os := l.seasonRow(o, rr.RaceNumber)
+		os.TeamPoints += pts
+		if o != d {
+			os.TeammatePoints += pts
+		}
+	}
+	team := l.teamOf(d)
+	team.SeasonPoints += pts
+	if !race {
+		return
+	}
+
+	s.Races += sign
+	if rr.PointsScored > 0 {
+		s.PointFinishes += sign
+	}
+	if rr.DNF {
+		s.DNFs += sign
+		team.DNFs += sign
+		if rr.DNFCause != "" && s.DNFCauses != nil {
+			s.DNFCauses[rr.DNFCause] += sign
+		}
+	} else if !rr.Disqualified && rr.FinishPosition > 0 && rr.FinishPosition <= 3 {
+		s.Podiums += sign
+		team.Podiums += sign
+		if rr.FinishPosition == 1 {
+			s.Wins += sign
+			team.Wins += sign
+		}
+	}
+}
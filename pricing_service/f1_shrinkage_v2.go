@@ -0,0 +1,195 @@
+package pricingservice
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// ============================================================
+//  BAYESIAN SHRINKAGE FOR LIVE-WINDOW METRICS
+// ============================================================
+//
+// dampedZ scales a Z-score by rows/5, which treats one great race in a
+// one-row window the same as a fifth of a great season. The shrinkage
+// estimator instead pulls each raw live metric toward a per-driver prior
+// with k pseudo-races of weight:
+//
+//	shrunk = (rows·observed + k·prior) / (rows + k)
+//
+// and Z-scores the shrunk values without any further damping. Priors:
+//
+//	REC    ((1−TeamMix)·PPR3y + TeamMix·team pts per car per race)
+//	       × recWindowWeight, the weight a full 5-race EWMA puts on a
+//	       steady rate (RecRaw is not normalised by its weights)
+//	CLUTCH points-per-race REC prior ÷ 12, clamped 0-1 (≈ share of top-5 finishes)
+//	FAST   ½·WIN3y + ½·grid mean fastest-lap rate
+//	GAIN   grid mean positions gained
+
+// F1LiveEstimatorV2 selects how live-window metrics become Z-scores.
+type F1LiveEstimatorV2 string
+
+const (
+	F1DampedEstimatorV2    F1LiveEstimatorV2 = "damped"    // Z · rows/5 (default)
+	F1ShrinkageEstimatorV2 F1LiveEstimatorV2 = "shrinkage" // shrink raws toward prior, then Z
+)
+
+// live metric keys used in F1CompleteDriverV2.LiveShrinkage
+const (
+	liveREC    = "REC"
+	liveGAIN   = "GAIN"
+	liveCLUTCH = "CLUTCH"
+	liveFAST   = "FAST"
+)
+
+var liveMetricKeys = []string{liveREC, liveGAIN, liveCLUTCH, liveFAST}
+
+// recWindowWeight is the total weight of RecRaw's EWMA over a full window,
+// 1 − (1−α)^5: a driver scoring x every race has RecRaw x·recWindowWeight.
+var recWindowWeight = 1 - math.Pow(1-recAlpha, 5)
+
+// F1ShrinkageV2 holds the shrinkage strength (pseudo-races) per live metric.
+type F1ShrinkageV2 struct {
+	Rec, Gain, Clutch, Fast float64
+	TeamMix                 float64 // weight of team strength in the REC prior
+}
+
+func NewF1ShrinkageV2() F1ShrinkageV2 {
+	return F1ShrinkageV2{Rec: 4, Gain: 3, Clutch: 5, Fast: 8, TeamMix: 0.30}
+}
+
+func (s F1ShrinkageV2) strength(key string) float64 {
+	switch key {
+	case liveREC:
+		return s.Rec
+	case liveGAIN:
+		return s.Gain
+	case liveCLUTCH:
+		return s.Clutch
+	case liveFAST:
+		return s.Fast
+	}
+	return 0
+}
+
+// F1ShrunkMetricV2 records one live metric under both estimators.
+type F1ShrunkMetricV2 struct {
+	Raw, Prior, Shrunk float64
+	Strength           float64 // k, pseudo-races of prior weight
+	ShrunkZ, DampedZ   float64
+}
+
+// liveAccess maps a live metric key to its raw value and its Z field.
+func liveAccess(key string, d *F1CompleteDriverV2) (raw float64, z *float64, clampIt bool) {
+	switch key {
+	case liveREC:
+		return d.RecRaw, &d.RECz, true
+	case liveGAIN:
+		return d.GainRaw, &d.GAINz, false // GAINz is never clamped
+	case liveCLUTCH:
+		return d.ClutchRaw, &d.ClutchZ, true
+	case liveFAST:
+		return d.FastLapRaw, &d.FastLapZ, true
+	}
+	return 0, nil, false
+}
+
+// livePriors builds every driver's prior for each live metric.
+func (model *F1QuantumPricingModelV2) livePriors(drvs []*F1CompleteDriverV2, teams map[string]*F1TeamDataV2) []map[string]float64 {
+	var gainMean, fastMean float64
+	for _, d := range drvs {
+		gainMean += d.GainRaw
+		fastMean += d.FastLapRaw
+	}
+	gainMean /= float64(len(drvs))
+	fastMean /= float64(len(drvs))
+
+	mix := model.Shrinkage.TeamMix
+	out := make([]map[string]float64, len(drvs))
+	for i, d := range drvs {
+		var carPPR float64
+		if t := teams[d.TeamID]; t != nil && t.CurrentRace > 0 {
+			carPPR = t.SeasonPoints / float64(t.CurrentRace) / 2
+		}
+		rec := (1-mix)*d.PPR3yRaw + mix*carPPR
+		out[i] = map[string]float64{
+			liveREC:    rec * recWindowWeight,
+			liveCLUTCH: clamp(rec/12, 0, 1),
+			liveFAST:   0.5*d.WIN3yRaw + 0.5*fastMean,
+			liveGAIN:   gainMean,
+		}
+	}
+	return out
+}
+
+// computeShrinkage fills LiveShrinkage for every driver and, when the
+// shrinkage estimator is selected, replaces the damped live Z-scores.
+// Must run after the 3-year raws are stored.
+func (model *F1QuantumPricingModelV2) computeShrinkage(drvs []*F1CompleteDriverV2, teams map[string]*F1TeamDataV2) {
+	if len(drvs) == 0 {
+		return
+	}
+	priors := model.livePriors(drvs, teams)
+	for _, key := range liveMetricKeys {
+		k := model.Shrinkage.strength(key)
+		shrunk := make([]float64, len(drvs))
+		for i, d := range drvs {
+			raw, z, _ := liveAccess(key, d)
+			n := float64(d.Rows)
+			shrunk[i] = raw
+			if n+k > 0 {
+				shrunk[i] = (n*raw + k*priors[i][key]) / (n + k)
+			}
+			if d.LiveShrinkage == nil {
+				d.LiveShrinkage = map[string]F1ShrunkMetricV2{}
+			}
+			d.LiveShrinkage[key] = F1ShrunkMetricV2{
+				Raw: raw, Prior: priors[i][key], Shrunk: shrunk[i],
+				Strength: k, DampedZ: *z,
+			}
+		}
+		mu, sd := meanStd(shrunk)
+		for i, d := range drvs {
+			_, z, clampIt := liveAccess(key, d)
+			sz := 0.0
+			if sd > 0 {
+				sz = (shrunk[i] - mu) / sd
+			}
+			if clampIt {
+				sz = clamp(sz, -3, 3)
+			}
+			e := d.LiveShrinkage[key]
+			e.ShrunkZ = sz
+			d.LiveShrinkage[key] = e
+			if model.LiveEstimator == F1ShrinkageEstimatorV2 {
+				*z = sz
+			}
+		}
+	}
+}
+
+// PrintShrinkageReport compares shrunk and damped live Z-scores per driver.
+func (model *F1QuantumPricingModelV2) PrintShrinkageReport(drvs []*F1CompleteDriverV2) {
+	fmt.Printf("\n=== LIVE METRICS: SHRUNK vs DAMPED Z (estimator: %s) ===\n", model.LiveEstimator)
+	fmt.Println(strings.Repeat("-", 100))
+	fmt.Printf("%-20s %-5s", "DRIVER", "ROWS")
+	for _, key := range liveMetricKeys {
+		fmt.Printf(" %-18s", fmt.Sprintf("%s k=%.0f", key, model.Shrinkage.strength(key)))
+	}
+	fmt.Println()
+	fmt.Printf("%-20s %-5s", "", "")
+	for range liveMetricKeys {
+		fmt.Printf(" %-8s %-9s", "SHRUNK", "DAMPED")
+	}
+	fmt.Println()
+	fmt.Println(strings.Repeat("-", 100))
+	for _, d := range drvs {
+		fmt.Printf("%-20s %-5d", d.BasicData.Name, d.Rows)
+		for _, key := range liveMetricKeys {
+			e := d.LiveShrinkage[key]
+			fmt.Printf(" %-8.2f %-9.2f", e.ShrunkZ, e.DampedZ)
+		}
+		fmt.Println()
+	}
+	fmt.Println(strings.Repeat("-", 100))
+}
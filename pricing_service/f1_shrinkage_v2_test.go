@@ -0,0 +1,60 @@
+package pricingservice
+
+import "testing"
+
+// steadyDrivers scores each driver the same points in five straight races,
+// at their 3-year rate.
+func steadyDrivers(pprs ...float64) []*F1CompleteDriverV2 {
+	var drvs []*F1CompleteDriverV2
+	for _, ppr := range pprs {
+		s := F1BasicSeasonStatsV2{Year: 2025}
+		for r := 1; r <= 5; r++ {
+			s.RecentRaces = append(s.RecentRaces, race(r, 5, 5, ppr, true))
+		}
+		d := &F1CompleteDriverV2{BasicData: F1BasicDriverDataV2{Seasons: []F1BasicSeasonStatsV2{s}}, PPR3yRaw: ppr}
+		d.attachLiveRaw()
+		drvs = append(drvs, d)
+	}
+	return drvs
+}
+
+func TestShrinkageRecOnPriorScale(t *testing.T) {
+	model := NewF1QuantumPricingModelV2()
+	model.Shrinkage.TeamMix = 0
+	drvs := steadyDrivers(10, 4)
+	model.computeShrinkage(drvs, nil)
+
+	// a driver scoring at their 3-year rate is not pulled anywhere: the
+	// prior is on the unnormalised EWMA's scale
+	for _, d := range drvs {
+		want := d.PPR3yRaw * recWindowWeight
+		if e := d.LiveShrinkage[liveREC]; !approx(e.Raw, want) || !approx(e.Prior, want) || !approx(e.Shrunk, want) {
+			t.Errorf("REC raw %v, prior %v, shrunk %v; want all %v", e.Raw, e.Prior, e.Shrunk, want)
+		}
+	}
+}
+
+func TestLiveEstimatorSelectsZ(t *testing.T) {
+	for _, est := range []F1LiveEstimatorV2{F1DampedEstimatorV2, F1ShrinkageEstimatorV2} {
+		model := NewF1QuantumPricingModelV2()
+		model.LiveEstimator = est
+		drvs := steadyDrivers(18, 10, 4, 1)
+		drvs[3].PPR3yRaw = 12 // a strong driver in a bad run is pulled up
+		computeLiveZ(drvs)
+		model.computeShrinkage(drvs, nil)
+
+		for _, d := range drvs {
+			e := d.LiveShrinkage[liveREC]
+			want := e.DampedZ
+			if est == F1ShrinkageEstimatorV2 {
+				want = e.ShrunkZ
+			}
+			if !approx(d.RECz, want) {
+				t.Errorf("%s: RECz = %v, want %v", est, d.RECz, want)
+			}
+		}
+		if e := drvs[3].LiveShrinkage[liveREC]; !(e.Shrunk > e.Raw && e.ShrunkZ > e.DampedZ) {
+			t.Errorf("%s: bad run with a strong prior: %+v", est, e)
+		}
+	}
+}
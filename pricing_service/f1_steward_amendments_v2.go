@@ -0,0 +1,317 @@
+package pricingservice
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// ============================================================
+//  STEWARD AMENDMENTS  (post-race penalties & disqualifications)
+// ============================================================
+//
+// Amendments change a race classification after the fact:
+//
+//	time-penalty  seconds added to the driver's gap; the round is
+//	              reclassified by laps down, then gap
+//	dsq           the driver drops out of the classification and
+//	              everyone behind moves up
+//	grid-penalty  records that StartPosition was a penalty grid slot;
+//	              GainRaw measures from the qualifying slot instead
+//
+// Finishes and points are recomputed for every driver whose position
+// moved, the RecentRaces entries are marked Amended with a note, and the
+// season rows and team snapshots are re-tallied through the season ledger.
+
+// F1AmendmentKindV2 is the kind of steward decision.
+type F1AmendmentKindV2 string
+
+const (
+	F1TimePenaltyV2      F1AmendmentKindV2 = "time-penalty"
+	F1DisqualificationV2 F1AmendmentKindV2 = "dsq"
+	F1GridPenaltyV2      F1AmendmentKindV2 = "grid-penalty"
+)
+
+// F1ResultAmendmentV2 is one steward decision on one driver's race result.
+type F1ResultAmendmentV2 struct {
+	Round   int
+	Driver  string
+	Kind    F1AmendmentKindV2
+	Seconds float64 // time-penalty
+	Places  int     // grid-penalty
+	Reason  string
+}
+
+// F1PointsSystemV2 awards race points by finishing position.
+type F1PointsSystemV2 struct {
+	Positions     []float64 // points for P1, P2, …
+	FastestLap    float64   // bonus for the fastest lap (0 = none, as from 2025)
+	FastestLapTop int       // bonus only inside this position
+}
+
+func NewF1PointsSystemV2() F1PointsSystemV2 {
+	return F1PointsSystemV2{
+		Positions:     []float64{25, 18, 15, 12, 10, 8, 6, 4, 2, 1},
+		FastestLapTop: 10,
+	}
+}
+
+func (p F1PointsSystemV2) award(pos int, fastestLap bool) float64 {
+	if pos < 1 || pos > len(p.Positions) {
+		return 0
+	}
+	pts := p.Positions[pos-1]
+	if fastestLap && pos <= p.FastestLapTop {
+		pts += p.FastestLap
+	}
+	return pts
+}
+
+// F1AmendmentLogV2 records how an amendment changed one result.
+type F1AmendmentLogV2 struct {
+	Round      int
+	Driver     string
+	PosBefore  int
+	PosAfter   int
+	PtsBefore  float64
+	PtsAfter   float64
+	GridPlaces int
+	Note       string
+}
+
+// LoadResultAmendmentsV2 reads and validates an amendments JSON file.
+func LoadResultAmendmentsV2(path string) ([]F1ResultAmendmentV2, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading amendments file: %v", err)
+	}
+	var out []F1ResultAmendmentV2
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, fmt.Errorf("error unmarshaling amendments: %v", err)
+	}
+	for i, a := range out {
+		if err := a.check(); err != nil {
+			return nil, fmt.Errorf("amendment %d: %v", i, err)
+		}
+	}
+	return out, nil
+}
+
+func (a F1ResultAmendmentV2) check() error {
+	if a.Driver == "" || a.Round <= 0 {
+		return fmt.Errorf("driver and round are required")
+	}
+	if a.Reason == "" {
+		return fmt.Errorf("%s round %d: reason is required", a.Driver, a.Round)
+	}
+	switch a.Kind {
+	case F1TimePenaltyV2:
+		if a.Seconds <= 0 {
+			return fmt.Errorf("%s round %d: time penalty needs Seconds > 0", a.Driver, a.Round)
+		}
+	case F1GridPenaltyV2:
+		if a.Places <= 0 {
+			return fmt.Errorf("%s round %d: grid penalty needs Places > 0", a.Driver, a.Round)
+		}
+	case F1DisqualificationV2:
+	default:
+		return fmt.Errorf("%s round %d: unknown amendment kind %q", a.Driver, a.Round, a.Kind)
+	}
+	return nil
+}
+
+func (a F1ResultAmendmentV2) note() string {
+	switch a.Kind {
+	case F1TimePenaltyV2:
+		return fmt.Sprintf("+%gs time penalty: %s", a.Seconds, a.Reason)
+	case F1GridPenaltyV2:
+		return fmt.Sprintf("%d-place grid penalty: %s", a.Places, a.Reason)
+	}
+	return "disqualified: " + a.Reason
+}
+
+// ApplyAmendments applies steward decisions to the drivers' live-season race
+// results. Call it after BuildTeamMapFromDrivers and before
+// PopulateDriverStats. Nothing is changed if any amendment is invalid.
+func (model *F1QuantumPricingModelV2) ApplyAmendments(drvs []*F1CompleteDriverV2, teams map[string]*F1TeamDataV2, amendments []F1ResultAmendmentV2) ([]F1AmendmentLogV2, error) {
+	l := newF1SeasonLedgerV2(drvs, teams)
+	byRound := make(map[int][]F1ResultAmendmentV2)
+	for _, a := range amendments {
+		byRound[a.Round] = append(byRound[a.Round], a)
+	}
+	rounds := make([]int, 0, len(byRound))
+	for r := range byRound {
+		rounds = append(rounds, r)
+	}
+	sort.Ints(rounds)
+
+	for _, r := range rounds {
+		if err := l.checkAmendments(r, byRound[r]); err != nil {
+			return nil, err
+		}
+	}
+	var out []F1AmendmentLogV2
+	for _, r := range rounds {
+		out = append(out, l.amendRound(r, byRound[r], model.Points)...)
+	}
+	return out, nil
+}
+
+// amendedEntry is one driver's result in the round being amended.
+type amendedEntry struct {
+	d            *F1CompleteDriverV2
+	before, next F1RaceResultV2
+}
+
+func (l *f1SeasonLedgerV2) roundEntries(round int) []*amendedEntry {
+	var out []*amendedEntry
+	for _, d := range l.drvs {
+		if rr := l.raceResult(d, round); rr != nil {
+			e := &amendedEntry{d: d, before: *rr, next: *rr}
+			e.next.AmendmentNotes = slices.Clone(rr.AmendmentNotes)
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// checkAmendments validates a round's amendments against the recorded results.
+func (l *f1SeasonLedgerV2) checkAmendments(round int, ams []F1ResultAmendmentV2) error {
+	timed := false
+	for _, a := range ams {
+		if err := a.check(); err != nil {
+			return err
+		}
+		d, ok := l.driver(a.Driver)
+		if !ok {
+			return fmt.Errorf("amendment for unknown driver %q", a.Driver)
+		}
+		if l.raceResult(d, round) == nil {
+			return fmt.Errorf("amendment for %s: no race result for round %d", d.BasicData.Name, round)
+		}
+		timed = timed || a.Kind == F1TimePenaltyV2
+	}
+	if !timed {
+		return nil
+	}
+	// a time penalty can only be placed if every finisher's gap is known
+	for _, e := range l.roundEntries(round) {
+		rr := e.before
+		if rr.Classified && !rr.Disqualified && rr.FinishPosition != 1 && rr.GapToLeaderSec == 0 && rr.LapsDown == 0 {
+			return fmt.Errorf("round %d: no race gap for %s; cannot apply a time penalty", round, e.d.BasicData.Name)
+		}
+	}
+	return nil
+}
+
+// amendRound applies one round's (validated) amendments and re-tallies
+// every result that changed.
+func (l *f1SeasonLedgerV2) amendRound(round int, ams []F1ResultAmendmentV2, points F1PointsSystemV2) []F1AmendmentLogV2 {
+	entries := l.roundEntries(round)
+	find := func(name string) *amendedEntry {
+		for _, e := range entries {
+			if strings.EqualFold(e.d.BasicData.Name, name) {
+				return e
+			}
+		}
+		return nil
+	}
+
+	timed, reclassify := false, false
+	for _, a := range ams {
+		e := find(a.Driver)
+		switch a.Kind {
+		case F1TimePenaltyV2:
+			e.next.GapToLeaderSec += a.Seconds
+			e.next.TimePenaltySec += a.Seconds
+			timed, reclassify = true, true
+		case F1DisqualificationV2:
+			e.next.Disqualified = true
+			e.next.Classified = false
+			reclassify = true
+		case F1GridPenaltyV2:
+			e.next.GridPenaltyPlaces += a.Places
+		}
+		e.next.AmendmentNotes = append(e.next.AmendmentNotes, a.note())
+		e.next.Amended = true
+	}
+
+	if reclassify {
+		// classified finishers, then unclassified, then disqualified
+		group := func(rr F1RaceResultV2) int {
+			switch {
+			case rr.Disqualified:
+				return 2
+			case !rr.Classified:
+				return 1
+			}
+			return 0
+		}
+		sort.SliceStable(entries, func(i, j int) bool {
+			a, b := entries[i].next, entries[j].next
+			if ga, gb := group(a), group(b); ga != gb {
+				return ga < gb
+			}
+			if timed && group(a) == 0 {
+				if a.LapsDown != b.LapsDown {
+					return a.LapsDown < b.LapsDown
+				}
+				if a.GapToLeaderSec != b.GapToLeaderSec {
+					return a.GapToLeaderSec < b.GapToLeaderSec
+				}
+			}
+			return a.FinishPosition < b.FinishPosition
+		})
+		for i, e := range entries {
+			pos := i + 1
+			if pos == e.before.FinishPosition && !e.next.Disqualified {
+				continue
+			}
+			e.next.FinishPosition = pos
+			e.next.PointsScored = 0
+			if group(e.next) == 0 {
+				e.next.PointsScored = points.award(pos, e.next.FastestLap)
+			}
+			if !e.next.Disqualified {
+				e.next.AmendmentNotes = append(e.next.AmendmentNotes,
+					fmt.Sprintf("P%d → P%d after reclassification", e.before.FinishPosition, pos))
+				e.next.Amended = true
+			}
+		}
+	}
+
+	var out []F1AmendmentLogV2
+	for _, e := range entries {
+		if len(e.next.AmendmentNotes) == len(e.before.AmendmentNotes) {
+			continue // untouched by this round's decisions
+		}
+		l.tally(e.d, e.before, -1, true)
+		*l.raceResult(e.d, round) = e.next
+		l.tally(e.d, e.next, 1, true)
+		out = append(out, F1AmendmentLogV2{
+			Round: round, Driver: e.d.BasicData.Name,
+			PosBefore: e.before.FinishPosition, PosAfter: e.next.FinishPosition,
+			PtsBefore: e.before.PointsScored, PtsAfter: e.next.PointsScored,
+			GridPlaces: e.next.GridPenaltyPlaces - e.before.GridPenaltyPlaces,
+			Note:       strings.Join(e.next.AmendmentNotes[len(e.before.AmendmentNotes):], "; "),
+		})
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].PosAfter < out[j].PosAfter })
+	return out
+}
+
+// PrintAmendmentLog prints every result changed by steward amendments.
+func PrintAmendmentLog(entries []F1AmendmentLogV2) {
+	fmt.Println("\n=== STEWARD AMENDMENTS ===")
+	fmt.Println(strings.Repeat("-", 100))
+	fmt.Printf("%-6s %-20s %-9s %-9s %-9s %-9s %s\n", "ROUND", "DRIVER", "POS WAS", "POS NOW", "PTS WAS", "PTS NOW", "NOTE")
+	fmt.Println(strings.Repeat("-", 100))
+	for _, e := range entries {
+		fmt.Printf("%-6d %-20s %-9d %-9d %-9.0f %-9.0f %s\n",
+			e.Round, e.Driver, e.PosBefore, e.PosAfter, e.PtsBefore, e.PtsAfter, e.Note)
+	}
+	fmt.Println(strings.Repeat("-", 100))
+}
@@ -0,0 +1,91 @@
+package pricingservice
+
+import "fmt"
+
+// ============================================================
+//  TEAM IDENTITY  (registry IDs behind every team lookup)
+// ============================================================
+//
+// NewDriver resolves the driver's current team through model.Teams and
+// rewrites every team name in the driver's data (current team, season
+// rows, race rows) to the name the registry gives that team in the season
+// being priced. "Red Bull" and "Red Bull Racing", or an Alfa Romeo season
+// and a Kick Sauber one, then compare equal wherever the pipeline compares
+// team names, so a spelling or a rebrand is never taken for a transfer.
+//
+// The team map is keyed by registry ID (F1CompleteDriverV2.TeamID).
+// PopulateDriverStats checks both before it starts: a name the registry
+// cannot resolve (the current team included; new teams go in the registry
+// file), or a driver whose team is missing from the map, is an error
+// rather than a nil team or a silently invented one further down.
+
+// canonicalTeams rewrites b's team names in place (on cloned slices) and
+// returns the ID of its current team ("" when it names none, or one the
+// registry does not know).
+func (m *F1QuantumPricingModelV2) canonicalTeams(b *F1BasicDriverDataV2) string {
+	id, _ := m.Teams.Resolve(b.TeamData.Name) // reported by checkTeams
+	year := b.SeasonYear()
+	name := func(n string) string {
+		if tid, err := m.Teams.Resolve(n); err == nil {
+			return m.Teams.NameIn(tid, year)
+		}
+		return n // left for checkTeams to report
+	}
+
+	*b = cloneBasicDriverV2(*b)
+	if id != "" {
+		b.TeamData.Name = m.Teams.NameIn(id, year)
+	}
+	if b.Team != "" {
+		b.Team = name(b.Team)
+	}
+	for i := range b.Seasons {
+		s := &b.Seasons[i]
+		if s.Team != "" {
+			s.Team = name(s.Team)
+		}
+		for j := range s.RecentRaces {
+			if rr := &s.RecentRaces[j]; rr.Team != "" {
+				rr.Team = name(rr.Team)
+			}
+		}
+	}
+	return id
+}
+
+// checkTeams verifies that every driver's team is in the map and that
+// every team name in their data resolves.
+func (m *F1QuantumPricingModelV2) checkTeams(drvs []*F1CompleteDriverV2, teams map[string]*F1TeamDataV2) error {
+	for _, d := range drvs {
+		b := &d.BasicData
+		if d.TeamID == "" {
+			if _, err := m.Teams.Resolve(b.TeamData.Name); err != nil && b.TeamData.Name != "" {
+				return fmt.Errorf("driver %q: %v", b.Name, err)
+			}
+			return fmt.Errorf("driver %q: no current team", b.Name)
+		}
+		if teams[d.TeamID] == nil {
+			return fmt.Errorf("driver %q: team %q (%s) is not in the team map", b.Name, b.TeamData.Name, d.TeamID)
+		}
+		if b.Team != "" {
+			if _, err := m.Teams.Resolve(b.Team); err != nil {
+				return fmt.Errorf("driver %q: %v", b.Name, err)
+			}
+		}
+		for _, s := range b.Seasons {
+			if s.Team != "" {
+				if _, err := m.Teams.Resolve(s.Team); err != nil {
+					return fmt.Errorf("driver %q, %d season: %v", b.Name, s.Year, err)
+				}
+			}
+			for _, rr := range s.RecentRaces {
+				if rr.Team != "" {
+					if _, err := m.Teams.Resolve(rr.Team); err != nil {
+						return fmt.Errorf("driver %q, %d round %d: %v", b.Name, s.Year, rr.RaceNumber, err)
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package pricingservice
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTeamIdentityAcrossSpellingsAndRebrands(t *testing.T) {
+	model := NewF1QuantumPricingModelV2()
+	basics := []F1BasicDriverDataV2{
+		{Name: "A", Team: "Red Bull", TeamData: F1TeamDataV2{Name: "Red Bull", CurrentRace: 4},
+			Seasons: []F1BasicSeasonStatsV2{{Year: 2025, Team: "Red Bull", Races: 4}}},
+		{Name: "B", Team: "Red Bull Racing", TeamData: F1TeamDataV2{Name: "Red Bull Racing", CurrentRace: 5},
+			Seasons: []F1BasicSeasonStatsV2{{Year: 2025, Team: "Oracle Red Bull Racing", Races: 5}}},
+		{Name: "C", Team: "Kick Sauber", TeamData: F1TeamDataV2{Name: "Kick Sauber", CurrentRace: 5},
+			Seasons: []F1BasicSeasonStatsV2{{Year: 2024, Team: "Stake F1 Team", Races: 24}, {Year: 2025, Team: "Kick Sauber", Races: 5}}},
+	}
+	drvs := model.NewDriverSet(basics)
+	teams := model.BuildTeamMapFromDrivers(drvs)
+
+	if len(teams) != 2 || drvs[0].TeamID != "red-bull" || teams["red-bull"].CurrentRace != 5 {
+		t.Fatalf("team map = %v, want one red-bull entry with the newest snapshot", teams)
+	}
+	if drvs[0].BasicData.TeamData.Name != "Red Bull Racing" || drvs[0].BasicData.Seasons[0].Team != "Red Bull Racing" {
+		t.Errorf("spelling not canonicalised: %+v", drvs[0].BasicData)
+	}
+	if basics[0].Seasons[0].Team != "Red Bull" {
+		t.Errorf("NewDriver rewrote the caller's data")
+	}
+	if s := drvs[2].BasicData.Seasons; s[0].Team != "Kick Sauber" || otherTeam(s[:1], drvs[2].TeamID, model.Teams) {
+		t.Errorf("rebranded season read as another team: %+v", s)
+	}
+	if err := model.PopulateDriverStats(drvs, teams); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPopulateDriverStatsTeamErrors(t *testing.T) {
+	model := NewF1QuantumPricingModelV2()
+	drvs := model.NewDriverSet([]F1BasicDriverDataV2{
+		{Name: "A", TeamData: F1TeamDataV2{Name: "Williams", CurrentRace: 3},
+			Seasons: []F1BasicSeasonStatsV2{{Year: 2024, Team: "Minardi", Races: 24}, {Year: 2025, Team: "Williams", Races: 3}}},
+	})
+	err := model.PopulateDriverStats(drvs, model.BuildTeamMapFromDrivers(drvs))
+	if err == nil || !strings.Contains(err.Error(), "Minardi") {
+		t.Errorf("unknown season team error = %v", err)
+	}
+
+	drvs = model.NewDriverSet([]F1BasicDriverDataV2{{Name: "A", TeamData: F1TeamDataV2{Name: "Wiliams", CurrentRace: 3}}})
+	err = model.PopulateDriverStats(drvs, model.BuildTeamMapFromDrivers(drvs))
+	if err == nil || !strings.Contains(err.Error(), `unknown team "Wiliams"`) {
+		t.Errorf("misspelt current team error = %v", err)
+	}
+	if _, err := model.Teams.Resolve("Wiliams"); err == nil {
+		t.Errorf("pricing registered a misspelt team")
+	}
+
+	drvs = model.NewDriverSet([]F1BasicDriverDataV2{{Name: "A", TeamData: F1TeamDataV2{Name: "Williams"}}})
+	if err := model.PopulateDriverStats(drvs, map[string]*F1TeamDataV2{}); err == nil || !strings.Contains(err.Error(), "team map") {
+		t.Errorf("missing team error = %v", err)
+	}
+}
+
+func TestTeamLookupsResolveRawNames(t *testing.T) {
+	teams := NewTeamRegistry()
+	stints := func(team string) []F1BasicSeasonStatsV2 {
+		return []F1BasicSeasonStatsV2{{Year: 2025, Team: team, Races: 5}}
+	}
+	if otherTeam(stints("Stake F1 Team"), "sauber", teams) || !otherTeam(stints("Ferrari"), "sauber", teams) {
+		t.Errorf("otherTeam did not compare registry IDs")
+	}
+
+	b := F1BasicDriverDataV2{Seasons: []F1BasicSeasonStatsV2{
+		{Year: 2025, Team: "Oracle Red Bull Racing", Races: 6, FromRound: 7},
+		{Year: 2025, Team: "VCARB", Races: 6, FromRound: 1},
+	}}
+	if got := b.racesWithCurrentTeam("red-bull", teams); got != 6 {
+		t.Errorf("races with current team = %d, want 6", got)
+	}
+
+	d := &F1CompleteDriverV2{TeamID: "red-bull", BasicData: F1BasicDriverDataV2{Seasons: []F1BasicSeasonStatsV2{{
+		Year: 2025, Team: "Red Bull", Races: 3, DNFCauses: map[F1DNFCauseV2]int{F1MechanicalDNFV2: 2},
+	}}}}
+	if mech, known := carDNFs([]*F1CompleteDriverV2{d}, teams); mech["red-bull"] != 2 || !known["red-bull"] {
+		t.Errorf("car DNFs = %v %v, want 2 for red-bull", mech, known)
+	}
+}
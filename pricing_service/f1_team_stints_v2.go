@@ -0,0 +1,156 @@
+package pricingservice
+
+import "sort"
+
+// ============================================================
+//  TEAM STINTS  (mid-season transfers & stand-in drivers)
+// ============================================================
+//
+// A driver who changes team during a season (transfer, swap or injury
+// stand-in) is described by one F1BasicSeasonStatsV2 row per team stint,
+// all sharing the same Year. Each stint row carries its own TeamPoints,
+// TeamPosition and TeammatePoints so team-context ratios are computed
+// against the car the driver was actually in. Driver-only counts (points,
+// wins, DNFs, races) are merged across stints.
+
+const (
+	defaultAdaptationDiscount = 0.20 // raw-score penalty on the first race with a new team
+	defaultAdaptationRaces    = 5    // races until the discount has faded out
+)
+
+// stintsByYear groups season rows by Year without touching the caller's slice.
+func stintsByYear(seasons []F1BasicSeasonStatsV2) map[int][]F1BasicSeasonStatsV2 {
+	out := make(map[int][]F1BasicSeasonStatsV2)
+	for _, s := range seasons {
+		out[s.Year] = append(out[s.Year], s)
+	}
+	for y := range out {
+		st := out[y]
+		sort.SliceStable(st, func(i, j int) bool { return st[i].FromRound < st[j].FromRound })
+	}
+	return out
+}
+
+// yearsNewestFirst returns the distinct season years, newest first.
+func yearsNewestFirst(byYear map[int][]F1BasicSeasonStatsV2) []int {
+	years := make([]int, 0, len(byYear))
+	for y := range byYear {
+		years = append(years, y)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(years)))
+	return years
+}
+
+// mergeStints folds same-year stint rows into a single season row. Driver
+// counts are summed; team-context fields are taken from the latest stint,
+// callers that need them per stint should use the stint rows directly.
+func mergeStints(stints []F1BasicSeasonStatsV2) F1BasicSeasonStatsV2 {
+	if len(stints) == 1 {
+		return stints[0]
+	}
+	last := stints[len(stints)-1]
+	out := F1BasicSeasonStatsV2{
+		Year:           last.Year,
+		Team:           last.Team,
+		TeamPoints:     last.TeamPoints,
+		TeamPosition:   last.TeamPosition,
+		TeammatePoints: last.TeammatePoints,
+		FromRound:      stints[0].FromRound,
+		ToRound:        last.ToRound,
+	}
+	for _, s := range stints {
+		out.Points += s.Points
+		out.Wins += s.Wins
+		out.Podiums += s.Podiums
+		out.Races += s.Races
+		out.PointFinishes += s.PointFinishes
+		out.DNFs += s.DNFs
+		for _, rr := range s.RecentRaces {
+			if rr.Team == "" {
+				rr.Team = s.Team
+			}
+			out.RecentRaces = append(out.RecentRaces, rr)
+		}
+		for cause, n := range s.dnfCauses() {
+			if out.DNFCauses == nil {
+				out.DNFCauses = make(map[F1DNFCauseV2]int)
+			}
+			out.DNFCauses[cause] += n
+		}
+	}
+	return out
+}
+
+// stintTeamContext returns race-weighted SHARE and CHAMP and the summed
+// teammate delta across the stints of one season.
+func stintTeamContext(stints []F1BasicSeasonStatsV2, grid int) (share, delta, champ float64) {
+	var races float64
+	for _, s := range stints {
+		w := float64(s.Races)
+		if w == 0 {
+			w = 1 // keep zero-race placeholder rows from dividing by zero
+		}
+		races += w
+		share += w * s.TeamShare()
+		champ += w * s.ChampPct(grid)
+		delta += s.MateDelta()
+	}
+	if races == 0 {
+		return 0.5, 0, 0
+	}
+	return share / races, delta, champ / races
+}
+
+// stintTeamShare is the race-weighted SHARE alone, for callers without a
+// grid size to place the team in.
+func stintTeamShare(stints []F1BasicSeasonStatsV2) float64 {
+	share, _, _ := stintTeamContext(stints, 0) // grid 0: CHAMP is left at 0
+	return share
+}
+
+// racesWithCurrentTeam prefers the explicit input field and otherwise
+// counts the races of the newest stints with the current team (registry
+// ID teamID).
+func (b *F1BasicDriverDataV2) racesWithCurrentTeam(teamID string, teams *TeamRegistry) int {
+	if b.RacesWithCurrentTeam > 0 {
+		return b.RacesWithCurrentTeam
+	}
+	byYear := stintsByYear(b.Seasons)
+	races := 0
+	for _, y := range b.seasonYears(-1) {
+		stints := byYear[y]
+		for i := len(stints) - 1; i >= 0; i-- {
+			if !teams.is(stints[i].Team, teamID) {
+				return races
+			}
+			races += stints[i].Races
+		}
+	}
+	return races
+}
+
+// changedTeam reports whether the driver is new to their current car: a
+// declared previous team, a mid-season stint change, or a reserve call-up.
+func (b *F1BasicDriverDataV2) changedTeam() bool {
+	if b.PreviousTeam != "" || b.IsReserve {
+		return true
+	}
+	if cur, ok := b.CurrentSeason(0); ok && cur.Team != "" {
+		return len(b.seasonStints(cur.Year)) > 1
+	}
+	return false
+}
+
+// attachAdaptation sets the transfer discount that fades linearly over the
+// first AdaptationRaces races with a new team.
+func (m *F1QuantumPricingModelV2) attachAdaptation(d *F1CompleteDriverV2) {
+	d.AdaptationRaw = 0
+	if m.AdaptationRaces <= 0 || !d.BasicData.changedTeam() {
+		return
+	}
+	done := float64(d.BasicData.racesWithCurrentTeam(d.TeamID, m.Teams)) / float64(m.AdaptationRaces)
+	if done >= 1 {
+		return
+	}
+	d.AdaptationRaw = m.AdaptationDiscount * (1 - done)
+}
@@ -0,0 +1,58 @@
+package pricingservice
+
+import "testing"
+
+// transferSeason is a 2025 season split 6 / 12 races between two cars.
+func transferSeason() []F1BasicSeasonStatsV2 {
+	return []F1BasicSeasonStatsV2{
+		{Year: 2025, Team: "Alpine", Races: 12, Points: 10, TeamPoints: 100, TeamPosition: 8, TeammatePoints: 20,
+			FromRound: 7, ToRound: 18, RecentRaces: []F1RaceResultV2{race(7, 12, 11, 0, true)}},
+		{Year: 2025, Team: "Haas", Races: 6, Points: 30, Wins: 1, TeamPoints: 60, TeamPosition: 2, TeammatePoints: 30,
+			FromRound: 1, ToRound: 6, RecentRaces: []F1RaceResultV2{race(6, 1, 1, 25, true)}},
+	}
+}
+
+func TestStintTeamContextWeightsByRaces(t *testing.T) {
+	share, delta, champ := stintTeamContext(transferSeason(), 10)
+	// share 0.5 over 6 races and 0.1 over 12; P2 and P8 of 10
+	if want := (6*0.5 + 12*0.1) / 18; !approx(share, want) {
+		t.Errorf("share = %v, want %v", share, want)
+	}
+	if want := (6*(1-1.0/9) + 12*(1-7.0/9)) / 18; !approx(champ, want) {
+		t.Errorf("champ = %v, want %v", champ, want)
+	}
+	if delta != -10 {
+		t.Errorf("delta = %v, want the summed -10", delta)
+	}
+	if got := stintTeamShare(transferSeason()); got != share {
+		t.Errorf("stintTeamShare = %v, want %v", got, share)
+	}
+
+	// a zero-race placeholder counts as one race instead of dividing by zero
+	placeholder := []F1BasicSeasonStatsV2{{Year: 2025, Points: 2, TeamPoints: 8, TeamPosition: 1}}
+	if share, _, champ := stintTeamContext(placeholder, 10); !approx(share, 0.25) || !approx(champ, 1) {
+		t.Errorf("placeholder stint = %v / %v", share, champ)
+	}
+	if share, delta, champ := stintTeamContext(nil, 10); share != 0.5 || delta != 0 || champ != 0 {
+		t.Errorf("no stints = %v / %v / %v", share, delta, champ)
+	}
+}
+
+func TestMergeStints(t *testing.T) {
+	byYear := stintsByYear(transferSeason())
+	stints := byYear[2025]
+	if stints[0].Team != "Haas" {
+		t.Fatalf("stints not ordered by FromRound: %q first", stints[0].Team)
+	}
+	m := mergeStints(stints)
+	if m.Races != 18 || m.Points != 40 || m.Wins != 1 || m.FromRound != 1 || m.ToRound != 18 {
+		t.Errorf("merged counts = %+v", m)
+	}
+	// team context is the latest car's; per-stint values go through stintTeamContext
+	if m.Team != "Alpine" || m.TeamPoints != 100 || m.TeamPosition != 8 {
+		t.Errorf("merged team context = %q %v P%d, want the Alpine stint", m.Team, m.TeamPoints, m.TeamPosition)
+	}
+	if len(m.RecentRaces) != 2 || m.RecentRaces[0].Team != "Haas" || m.RecentRaces[1].Team != "Alpine" {
+		t.Errorf("merged races not tagged with their car: %+v", m.RecentRaces)
+	}
+}
@@ -0,0 +1,170 @@
+package pricingservice
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ============================================================
+//  TEAMMATE HEAD-TO-HEAD  ("beat the teammate")
+// ============================================================
+//
+// Drivers are paired by car and round from their live-season race results,
+// so a mid-season swap pairs a driver with whoever drove the other car that
+// weekend. Over the paired rounds each driver gets:
+//
+//	qualifying H2H   share of rounds out-qualifying the teammate (pace grid)
+//	race H2H         share of rounds finishing ahead, both classified
+//	finish gap       mean (teammate finish − own finish), both classified
+//	points share     own points ÷ pair points
+//
+// Each is Z-scored over the grid; H2HZ blends them and is damped by the
+// number of paired rounds the same way the live window is (pairs/5).
+
+// F1TeammateH2HV2 holds one driver's head-to-head record against teammates.
+type F1TeammateH2HV2 struct {
+	Pairs                       int // rounds with a teammate result
+	QualiWins, QualiContests    int
+	RaceWins, RaceContests      int
+	QualiRate, RaceRate         float64
+	AvgFinishGap, PointsShare   float64
+	QualiZ, RaceZ, GapZ, ShareZ float64
+}
+
+// blend weights of the four head-to-head Z-scores in H2HZ
+const (
+	h2hQualiW = 0.30
+	h2hRaceW  = 0.30
+	h2hGapW   = 0.20
+	h2hShareW = 0.20
+)
+
+type h2hEntry struct {
+	d  *F1CompleteDriverV2
+	rr F1RaceResultV2
+}
+
+func (rr F1RaceResultV2) classifiedFinish() bool {
+	return rr.Classified && !rr.Disqualified && rr.FinishPosition > 0
+}
+
+// ComputeTeammateH2H pairs every driver with their teammates round by round
+// (teams matched by registry ID) and fills H2H and H2HZ.
+func ComputeTeammateH2H(drvs []*F1CompleteDriverV2, teams *TeamRegistry) {
+	byCar := make(map[string][]h2hEntry)
+	var keys []string
+	for _, d := range drvs {
+		cur, ok := d.BasicData.CurrentSeason(0)
+		if !ok {
+			continue
+		}
+		for _, rr := range cur.RecentRaces {
+			team := rr.Team
+			if team == "" {
+				team = cur.Team
+			}
+			if team == "" {
+				team = d.BasicData.Team
+			}
+			id, err := teams.Resolve(team)
+			if err != nil {
+				continue // reported by checkTeams
+			}
+			key := fmt.Sprintf("%s#%d", id, rr.RaceNumber)
+			if _, seen := byCar[key]; !seen {
+				keys = append(keys, key)
+			}
+			byCar[key] = append(byCar[key], h2hEntry{d, rr})
+		}
+	}
+	sort.Strings(keys)
+
+	type tally struct {
+		h2h             F1TeammateH2HV2
+		gapSum, gapN    float64
+		ownPts, pairPts float64
+	}
+	tallies := make(map[*F1CompleteDriverV2]*tally, len(drvs))
+	for _, d := range drvs {
+		tallies[d] = &tally{}
+	}
+	for _, key := range keys {
+		car := byCar[key]
+		for i, a := range car {
+			for j, b := range car {
+				if i == j || a.d == b.d {
+					continue
+				}
+				t := tallies[a.d]
+				t.h2h.Pairs++
+				ga := paceGrid(a.rr.QualifyingPosition, a.rr.StartPosition, a.rr.GridPenaltyPlaces)
+				gb := paceGrid(b.rr.QualifyingPosition, b.rr.StartPosition, b.rr.GridPenaltyPlaces)
+				if ga > 0 && gb > 0 {
+					t.h2h.QualiContests++
+					if ga < gb {
+						t.h2h.QualiWins++
+					}
+				}
+				if a.rr.classifiedFinish() && b.rr.classifiedFinish() {
+					t.h2h.RaceContests++
+					if a.rr.FinishPosition < b.rr.FinishPosition {
+						t.h2h.RaceWins++
+					}
+					t.gapSum += float64(b.rr.FinishPosition - a.rr.FinishPosition)
+					t.gapN++
+				}
+				t.ownPts += a.rr.PointsScored
+				t.pairPts += a.rr.PointsScored + b.rr.PointsScored
+			}
+		}
+	}
+
+	rate := func(wins, contests int) float64 {
+		if contests == 0 {
+			return 0.5
+		}
+		return float64(wins) / float64(contests)
+	}
+	for _, d := range drvs {
+		t := tallies[d]
+		t.h2h.QualiRate = rate(t.h2h.QualiWins, t.h2h.QualiContests)
+		t.h2h.RaceRate = rate(t.h2h.RaceWins, t.h2h.RaceContests)
+		t.h2h.PointsShare = 0.5
+		if t.pairPts > 0 {
+			t.h2h.PointsShare = t.ownPts / t.pairPts
+		}
+		if t.gapN > 0 {
+			t.h2h.AvgFinishGap = t.gapSum / t.gapN
+		}
+		d.H2H = t.h2h
+	}
+
+	zBatch(drvs, func(x *F1CompleteDriverV2) float64 { return x.H2H.QualiRate }, func(x *F1CompleteDriverV2, z float64) { x.H2H.QualiZ = z })
+	zBatch(drvs, func(x *F1CompleteDriverV2) float64 { return x.H2H.RaceRate }, func(x *F1CompleteDriverV2, z float64) { x.H2H.RaceZ = z })
+	zBatch(drvs, func(x *F1CompleteDriverV2) float64 { return x.H2H.AvgFinishGap }, func(x *F1CompleteDriverV2, z float64) { x.H2H.GapZ = z })
+	zBatch(drvs, func(x *F1CompleteDriverV2) float64 { return x.H2H.PointsShare }, func(x *F1CompleteDriverV2, z float64) { x.H2H.ShareZ = z })
+	for _, d := range drvs {
+		h := d.H2H
+		blend := h2hQualiW*h.QualiZ + h2hRaceW*h.RaceZ + h2hGapW*h.GapZ + h2hShareW*h.ShareZ
+		d.H2HZ = blend * min(1, float64(h.Pairs)/5.0) // few pairs ⇒ damped
+	}
+}
+
+// PrintTeammateH2HTable prints each driver's record against their teammates.
+func PrintTeammateH2HTable(drvs []*F1CompleteDriverV2) {
+	fmt.Println("\n=== TEAMMATE HEAD-TO-HEAD ===")
+	fmt.Println(strings.Repeat("-", 100))
+	fmt.Printf("%-20s %-16s %-6s %-8s %-8s %-8s %-8s %-8s\n",
+		"DRIVER", "TEAM", "PAIRS", "QUALI", "RACE", "GAP", "PTS %", "H2H Z")
+	fmt.Println(strings.Repeat("-", 100))
+	for _, d := range drvs {
+		h := d.H2H
+		fmt.Printf("%-20s %-16s %-6d %-8s %-8s %-8.2f %-8.0f %-8.2f\n",
+			d.BasicData.Name, d.BasicData.Team, h.Pairs,
+			fmt.Sprintf("%d-%d", h.QualiWins, h.QualiContests-h.QualiWins),
+			fmt.Sprintf("%d-%d", h.RaceWins, h.RaceContests-h.RaceWins),
+			h.AvgFinishGap, h.PointsShare*100, d.H2HZ)
+	}
+	fmt.Println(strings.Repeat("-", 100))
+}
@@ -0,0 +1,71 @@
+package pricingservice
+
+import "testing"
+
+func h2hDriver(name, team string, races ...F1RaceResultV2) *F1CompleteDriverV2 {
+	return &F1CompleteDriverV2{BasicData: F1BasicDriverDataV2{
+		Name: name, Team: team,
+		Seasons: []F1BasicSeasonStatsV2{{Year: 2025, Team: team, RecentRaces: races}},
+	}}
+}
+
+func TestTeammateH2HPairsByRound(t *testing.T) {
+	// C replaces B in the second Williams car for round 3; D has no teammate
+	a := h2hDriver("A", "Williams", race(1, 5, 6, 8, true), race(2, 9, 10, 1, true), race(3, 4, 5, 10, true))
+	b := h2hDriver("B", "Williams", race(1, 7, 8, 4, true), race(2, 8, 9, 2, true))
+	c := h2hDriver("C", "williams ", race(3, 12, 0, 0, false))
+	d := h2hDriver("D", "Haas", race(1, 10, 11, 0, true), race(2, 11, 12, 0, true), race(3, 10, 11, 0, true))
+	drvs := []*F1CompleteDriverV2{a, b, c, d}
+	ComputeTeammateH2H(drvs, NewTeamRegistry())
+
+	tests := []struct {
+		d             *F1CompleteDriverV2
+		pairs         int
+		quali, race   float64
+		qualiN, raceN int
+		gap, share    float64
+	}{
+		{a, 3, 2.0 / 3, 1.0 / 2, 3, 2, 0.5, 19.0 / 25},
+		{b, 2, 1.0 / 2, 1.0 / 2, 2, 2, -0.5, 6.0 / 15},
+		{c, 1, 0, 0.5, 1, 0, 0, 0}, // a DNF is no race contest
+		{d, 0, 0.5, 0.5, 0, 0, 0, 0.5},
+	}
+	for _, tt := range tests {
+		h := tt.d.H2H
+		name := tt.d.BasicData.Name
+		if h.Pairs != tt.pairs || h.QualiContests != tt.qualiN || h.RaceContests != tt.raceN {
+			t.Errorf("%s: %d pairs, %d quali / %d race contests; want %d, %d / %d",
+				name, h.Pairs, h.QualiContests, h.RaceContests, tt.pairs, tt.qualiN, tt.raceN)
+		}
+		if !approx(h.QualiRate, tt.quali) || !approx(h.RaceRate, tt.race) ||
+			!approx(h.AvgFinishGap, tt.gap) || !approx(h.PointsShare, tt.share) {
+			t.Errorf("%s: quali %v race %v gap %v share %v; want %v %v %v %v",
+				name, h.QualiRate, h.RaceRate, h.AvgFinishGap, h.PointsShare, tt.quali, tt.race, tt.gap, tt.share)
+		}
+
+		// the blend is damped by pairs/5, like the live window
+		blend := h2hQualiW*h.QualiZ + h2hRaceW*h.RaceZ + h2hGapW*h.GapZ + h2hShareW*h.ShareZ
+		if want := blend * float64(tt.pairs) / 5; !approx(tt.d.H2HZ, want) {
+			t.Errorf("%s: H2HZ = %v, want %v (%d pairs)", name, tt.d.H2HZ, want, tt.pairs)
+		}
+	}
+	if d.H2HZ != 0 {
+		t.Errorf("a driver without a teammate has H2HZ %v", d.H2HZ)
+	}
+}
+
+func TestTeammateH2HDampingCapsAtFivePairs(t *testing.T) {
+	var aRaces, bRaces []F1RaceResultV2
+	for r := 1; r <= 7; r++ {
+		aRaces = append(aRaces, race(r, 3, 3, 15, true))
+		bRaces = append(bRaces, race(r, 6, 6, 8, true))
+	}
+	a, b := h2hDriver("A", "Haas", aRaces...), h2hDriver("B", "Haas", bRaces...)
+	ComputeTeammateH2H([]*F1CompleteDriverV2{a, b}, NewTeamRegistry())
+
+	h := a.H2H
+	blend := h2hQualiW*h.QualiZ + h2hRaceW*h.RaceZ + h2hGapW*h.GapZ + h2hShareW*h.ShareZ
+	if h.Pairs != 7 || !approx(a.H2HZ, blend) || a.H2HZ <= 0 {
+		t.Errorf("7 pairs: H2HZ = %v, want the undamped %v", a.H2HZ, blend)
+	}
+}
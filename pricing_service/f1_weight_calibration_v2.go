@@ -0,0 +1,543 @@
+package pricingservice
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strings"
+
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/stat"
+)
+
+// ============================================================
+//  WEIGHT CALIBRATION  (fit rawScore weights to fantasy points)
+// ============================================================
+//
+// Each calibration round is a snapshot of the driver inputs as they stood
+// before a round, plus the fantasy points each driver went on to score in
+// it. The snapshot is run through the normal v2 pipeline (NewDriverSet →
+// BuildTeamMapFromDrivers → PopulateDriverStats) and the value of every
+// scoreTerm becomes one feature row, so the fit sees exactly what rawScore
+// sees:
+//
+//	points ≈ a + Σ β_j · term_j
+//
+// The fit is ridge regression on standardised features, with the penalty
+// λ picked by leave-one-round-out cross-validation (every round is held
+// out once and predicted from the others). The fit quality reported with
+// it comes from nested cross-validation: each round is held out and
+// predicted with a λ picked on the other rounds alone, so the round that
+// scores a λ never helped choose it. Terms that never vary in the
+// data cannot be fitted and keep their current weight, as do Bias and the
+// adaptation discount. The fitted β are in points per unit of the term, so
+// they are rescaled to the L1 norm of the weights they replace: a profile
+// changes the mix of the score, not its spread.
+
+var defaultCalibrationLambdas = []float64{0.01, 0.03, 0.1, 0.3, 1, 3, 10}
+
+// F1CalibrationRoundV2 is the model input before one historical round and
+// the fantasy points scored in it, keyed by driver name.
+type F1CalibrationRoundV2 struct {
+	Season  int
+	Round   int
+	Drivers []F1BasicDriverDataV2
+	Points  map[string]float64
+}
+
+func (r F1CalibrationRoundV2) label() string {
+	return fmt.Sprintf("%d R%d", r.Season, r.Round)
+}
+
+// LoadCalibrationRoundsV2 reads a calibration JSON file (an array of rounds).
+func LoadCalibrationRoundsV2(path string) ([]F1CalibrationRoundV2, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading calibration file: %v", err)
+	}
+	var out []F1CalibrationRoundV2
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, fmt.Errorf("error unmarshaling calibration rounds: %v", err)
+	}
+	for i, r := range out {
+		if len(r.Drivers) == 0 || len(r.Points) == 0 {
+			return nil, fmt.Errorf("calibration round %d (%s): drivers and points are required", i, r.label())
+		}
+	}
+	return out, nil
+}
+
+// F1CalibrationConfigV2 sets the ridge penalties tried by cross-validation.
+type F1CalibrationConfigV2 struct {
+	Lambdas []float64
+}
+
+func NewF1CalibrationConfigV2() F1CalibrationConfigV2 {
+	return F1CalibrationConfigV2{Lambdas: defaultCalibrationLambdas}
+}
+
+// F1CalibrationFoldV2 is the out-of-sample fit on one held-out round.
+type F1CalibrationFoldV2 struct {
+	Round    string
+	Drivers  int
+	Lambda   float64 // λ picked without this round
+	RMSE     float64
+	R2       float64
+	Spearman float64 // rank correlation of predicted vs realized points
+	Baseline float64 // rank correlation of the weights in use vs realized points
+}
+
+// F1CalibrationTermV2 compares one term's weight in use with its fit.
+type F1CalibrationTermV2 struct {
+	Term    string
+	Current float64
+	Fitted  float64
+	Beta    float64 // points per unit of the term, before rescaling
+	Free    bool    // false when the term never varied and kept its weight
+}
+
+// F1CalibrationReportV2 is the result of CalibrateWeights.
+type F1CalibrationReportV2 struct {
+	Rounds, Rows int
+	Lambda       float64
+	LambdaRMSE   map[float64]float64   // pooled CV RMSE per λ tried (in-sample for the λ picked)
+	Folds        []F1CalibrationFoldV2 // nested CV, one per held-out round
+	Terms        []F1CalibrationTermV2
+	Profile      F1WeightProfileV2
+}
+
+// CV returns the fold averages of RMSE, R² and both rank correlations.
+func (r F1CalibrationReportV2) CV() (rmse, r2, spearman, baseline float64) {
+	if len(r.Folds) == 0 {
+		return 0, 0, 0, 0
+	}
+	for _, f := range r.Folds {
+		rmse += f.RMSE
+		r2 += f.R2
+		spearman += f.Spearman
+		baseline += f.Baseline
+	}
+	n := float64(len(r.Folds))
+	return rmse / n, r2 / n, spearman / n, baseline / n
+}
+
+// calibrationRow is one driver-round: term values, realized points, and
+// the rawScore of the weights in use.
+type calibrationRow struct {
+	fold  int
+	x     []float64
+	y     float64
+	score float64
+}
+
+// calibrationRows runs every round through the pipeline; drivers with no
+// points entry are left out of the fit. A term Z-scored over a round where
+// it did not vary comes out NaN and is read as 0, the grid mean.
+func (model *F1QuantumPricingModelV2) calibrationRows(rounds []F1CalibrationRoundV2) ([]calibrationRow, error) {
+	var rows []calibrationRow
+	for f, r := range rounds {
+		points := make(map[string]float64, len(r.Points))
+		for name, p := range r.Points {
+			points[strings.ToLower(name)] = p
+		}
+		drvs := model.NewDriverSet(r.Drivers)
+		if err := model.PopulateDriverStats(drvs, model.BuildTeamMapFromDrivers(drvs)); err != nil {
+			return nil, fmt.Errorf("calibration round %d-%d: %v", r.Season, r.Round, err)
+		}
+		for _, d := range drvs {
+			y, ok := points[strings.ToLower(d.BasicData.Name)]
+			if !ok {
+				continue
+			}
+			x := make([]float64, len(scoreTerms))
+			for j, t := range scoreTerms {
+				if v := t.value(d); !math.IsNaN(v) && !math.IsInf(v, 0) {
+					x[j] = v
+				}
+			}
+			rows = append(rows, calibrationRow{fold: f, x: x, y: y, score: model.Weights.rawScore(d)})
+		}
+	}
+	return rows, nil
+}
+
+// ridgeFit is a ridge regression on standardised features; sd = 0 marks a
+// feature that did not vary and has β = 0.
+type ridgeFit struct {
+	mean, sd, beta []float64
+	intercept      float64
+}
+
+func (f ridgeFit) predict(x []float64) float64 {
+	y := f.intercept
+	for j, b := range f.beta {
+		y += b * x[j]
+	}
+	return y
+}
+
+// fitRidge solves (ZᵀZ + λ·n·I) b = Zᵀ(y − ȳ) on the free (varying)
+// features and maps b back to the raw feature scale.
+func fitRidge(rows []calibrationRow, lambda float64) (ridgeFit, error) {
+	n, p := len(rows), len(rows[0].x)
+	fit := ridgeFit{mean: make([]float64, p), sd: make([]float64, p), beta: make([]float64, p)}
+	col := make([]float64, n)
+	var free []int
+	for j := 0; j < p; j++ {
+		for i, r := range rows {
+			col[i] = r.x[j]
+		}
+		fit.mean[j], fit.sd[j] = stat.PopMeanStdDev(col, nil)
+		if fit.sd[j] > 1e-9 {
+			free = append(free, j)
+		} else {
+			fit.sd[j] = 0
+		}
+	}
+	ys := make([]float64, n)
+	for i, r := range rows {
+		ys[i] = r.y
+	}
+	yMean := stat.Mean(ys, nil)
+	fit.intercept = yMean
+	if len(free) == 0 {
+		return fit, nil
+	}
+
+	z := mat.NewDense(n, len(free), nil)
+	for i, r := range rows {
+		for k, j := range free {
+			z.Set(i, k, (r.x[j]-fit.mean[j])/fit.sd[j])
+		}
+	}
+	yc := mat.NewVecDense(n, nil)
+	for i, y := range ys {
+		yc.SetVec(i, y-yMean)
+	}
+
+	var a mat.SymDense
+	a.SymOuterK(1, z.T())
+	for k := range free {
+		a.SetSym(k, k, a.At(k, k)+lambda*float64(n))
+	}
+	var rhs, b mat.VecDense
+	rhs.MulVec(z.T(), yc)
+	var chol mat.Cholesky
+	if !chol.Factorize(&a) {
+		return fit, fmt.Errorf("ridge system not positive definite (λ %.3g)", lambda)
+	}
+	if err := chol.SolveVecTo(&b, &rhs); err != nil {
+		return fit, fmt.Errorf("error solving ridge system: %v", err)
+	}
+	for k, j := range free {
+		fit.beta[j] = b.AtVec(k) / fit.sd[j]
+		fit.intercept -= fit.beta[j] * fit.mean[j]
+	}
+	return fit, nil
+}
+
+// splitFold separates the rows of round f from the rest.
+func splitFold(rows []calibrationRow, f int) (train, test []calibrationRow) {
+	for _, row := range rows {
+		if row.fold == f {
+			test = append(test, row)
+		} else {
+			train = append(train, row)
+		}
+	}
+	return train, test
+}
+
+// scoreFold fits train with lambda and scores the prediction of test; it
+// also returns the fold's squared error.
+func scoreFold(train, test []calibrationRow, lambda float64) (F1CalibrationFoldV2, float64, error) {
+	fit, err := fitRidge(train, lambda)
+	if err != nil {
+		return F1CalibrationFoldV2{}, 0, err
+	}
+	pred, real, score := make([]float64, len(test)), make([]float64, len(test)), make([]float64, len(test))
+	var sse, sst float64
+	yMean := 0.0
+	for i, row := range test {
+		pred[i], real[i], score[i] = fit.predict(row.x), row.y, row.score
+		yMean += row.y / float64(len(test))
+	}
+	for i := range test {
+		sse += (pred[i] - real[i]) * (pred[i] - real[i])
+		sst += (real[i] - yMean) * (real[i] - yMean)
+	}
+	r2 := 0.0
+	if sst > 0 {
+		r2 = 1 - sse/sst
+	}
+	return F1CalibrationFoldV2{
+		Drivers:  len(test),
+		Lambda:   lambda,
+		RMSE:     math.Sqrt(sse / float64(len(test))),
+		R2:       r2,
+		Spearman: spearman(pred, real),
+		Baseline: spearman(score, real),
+	}, sse, nil
+}
+
+// crossValidate holds out each round in turn and returns the pooled RMSE
+// of predicting it from a fit on the remaining rounds.
+func crossValidate(rows []calibrationRow, rounds []F1CalibrationRoundV2, lambda float64) (float64, error) {
+	var sse float64
+	var m int
+	for f := range rounds {
+		train, test := splitFold(rows, f)
+		if len(test) == 0 || len(train) == 0 {
+			continue
+		}
+		_, foldSSE, err := scoreFold(train, test, lambda)
+		if err != nil {
+			return 0, err
+		}
+		sse += foldSSE
+		m += len(test)
+	}
+	if m == 0 {
+		return 0, fmt.Errorf("no round could be held out")
+	}
+	return math.Sqrt(sse / float64(m)), nil
+}
+
+// selectLambda returns the λ with the lowest cross-validated RMSE on rows,
+// and the RMSE of every λ tried.
+func selectLambda(rows []calibrationRow, rounds []F1CalibrationRoundV2, lambdas []float64) (float64, map[float64]float64, error) {
+	byLambda := make(map[float64]float64, len(lambdas))
+	best, bestRMSE := 0.0, math.Inf(1)
+	for _, l := range lambdas {
+		rmse, err := crossValidate(rows, rounds, l)
+		if err != nil {
+			return 0, nil, err
+		}
+		byLambda[l] = rmse
+		if rmse < bestRMSE {
+			best, bestRMSE = l, rmse
+		}
+	}
+	return best, byLambda, nil
+}
+
+// nestedCV holds out each round in turn, picks λ by cross-validation on the
+// remaining rounds only, and scores the held-out round with that λ.
+func nestedCV(rows []calibrationRow, rounds []F1CalibrationRoundV2, lambdas []float64) ([]F1CalibrationFoldV2, error) {
+	var folds []F1CalibrationFoldV2
+	for f, r := range rounds {
+		train, test := splitFold(rows, f)
+		if len(test) == 0 || len(train) == 0 {
+			continue
+		}
+		lambda, _, err := selectLambda(train, rounds, lambdas)
+		if err != nil {
+			return nil, fmt.Errorf("round %s held out: %v", r.label(), err)
+		}
+		fold, _, err := scoreFold(train, test, lambda)
+		if err != nil {
+			return nil, err
+		}
+		fold.Round = r.label()
+		folds = append(folds, fold)
+	}
+	return folds, nil
+}
+
+// spearman is the Pearson correlation of average ranks (0 if either side
+// is constant).
+func spearman(a, b []float64) float64 {
+	ra, rb := averageRanks(a), averageRanks(b)
+	_, sa := stat.PopMeanStdDev(ra, nil)
+	_, sb := stat.PopMeanStdDev(rb, nil)
+	if sa == 0 || sb == 0 {
+		return 0
+	}
+	return stat.Correlation(ra, rb, nil)
+}
+
+func averageRanks(x []float64) []float64 {
+	idx := make([]int, len(x))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(a, b int) bool { return x[idx[a]] < x[idx[b]] })
+	ranks := make([]float64, len(x))
+	for i := 0; i < len(idx); {
+		j := i
+		for j+1 < len(idx) && x[idx[j+1]] == x[idx[i]] {
+			j++
+		}
+		for k := i; k <= j; k++ {
+			ranks[idx[k]] = float64(i+j)/2 + 1
+		}
+		i = j + 1
+	}
+	return ranks
+}
+
+// CalibrateWeights fits the rawScore weights to the realized points of the
+// calibration rounds. The model itself is not changed; load the returned
+// profile with UseWeightProfile.
+func (model *F1QuantumPricingModelV2) CalibrateWeights(rounds []F1CalibrationRoundV2, cfg F1CalibrationConfigV2) (F1CalibrationReportV2, error) {
+	if len(rounds) < 3 {
+		return F1CalibrationReportV2{}, fmt.Errorf("calibration needs at least 3 rounds (nested cross-validation), got %d", len(rounds))
+	}
+	rows, err := model.calibrationRows(rounds)
+	if err != nil {
+		return F1CalibrationReportV2{}, err
+	}
+	if len(rows) == 0 {
+		return F1CalibrationReportV2{}, fmt.Errorf("no driver in the calibration rounds has a points entry")
+	}
+	lambdas := cfg.Lambdas
+	if len(lambdas) == 0 {
+		lambdas = defaultCalibrationLambdas
+	}
+
+	report := F1CalibrationReportV2{Rounds: len(rounds), Rows: len(rows)}
+	if report.Lambda, report.LambdaRMSE, err = selectLambda(rows, rounds, lambdas); err != nil {
+		return F1CalibrationReportV2{}, err
+	}
+	if report.Folds, err = nestedCV(rows, rounds, lambdas); err != nil {
+		return F1CalibrationReportV2{}, err
+	}
+
+	fit, err := fitRidge(rows, report.Lambda)
+	if err != nil {
+		return F1CalibrationReportV2{}, err
+	}
+
+	// rescale the fitted β to the L1 norm of the weights they replace
+	weights := model.Weights
+	var l1Current, l1Beta float64
+	for j, t := range scoreTerms {
+		if fit.sd[j] > 0 {
+			l1Current += math.Abs(*t.weight(&weights))
+			l1Beta += math.Abs(fit.beta[j])
+		}
+	}
+	scale := 0.0
+	if l1Beta > 0 {
+		scale = l1Current / l1Beta
+	}
+	for j, t := range scoreTerms {
+		w := t.weight(&weights)
+		term := F1CalibrationTermV2{Term: t.name, Current: *w, Fitted: *w, Beta: fit.beta[j], Free: fit.sd[j] > 0}
+		if term.Free && scale > 0 {
+			*w = fit.beta[j] * scale
+			term.Fitted = *w
+		}
+		report.Terms = append(report.Terms, term)
+	}
+
+	rmse, r2, rho, _ := report.CV()
+	report.Profile = F1WeightProfileV2{
+		Rounds:     report.Rounds,
+		Rows:       report.Rows,
+		Lambda:     report.Lambda,
+		CVRMSE:     rmse,
+		CVR2:       r2,
+		CVSpearman: rho,
+		Weights:    weights,
+	}
+	return report, nil
+}
+
+// PrintCalibrationReport prints the λ search, the nested cross-validation
+// folds and the fitted weights next to the weights in use.
+func PrintCalibrationReport(r F1CalibrationReportV2) {
+	fmt.Println("\n=== WEIGHT CALIBRATION (V2) ===")
+	fmt.Printf("%d rounds, %d driver-rounds, ridge λ %.3g (leave-one-round-out)\n", r.Rounds, r.Rows, r.Lambda)
+	fmt.Println("λ search (in-sample for the λ picked):")
+	lambdas := make([]float64, 0, len(r.LambdaRMSE))
+	for l := range r.LambdaRMSE {
+		lambdas = append(lambdas, l)
+	}
+	sort.Float64s(lambdas)
+	for _, l := range lambdas {
+		fmt.Printf("  λ %-7.3g CV RMSE %.3f\n", l, r.LambdaRMSE[l])
+	}
+
+	fmt.Println("\nNested cross-validation (λ picked without the held-out round):")
+	fmt.Println(strings.Repeat("-", 72))
+	fmt.Printf("%-12s %-8s %-8s %-8s %-8s %-10s %-10s\n", "ROUND", "DRIVERS", "λ", "RMSE", "R2", "RHO FIT", "RHO NOW")
+	fmt.Println(strings.Repeat("-", 72))
+	for _, f := range r.Folds {
+		fmt.Printf("%-12s %-8d %-8.3g %-8.3f %-8.3f %-10.3f %-10.3f\n", f.Round, f.Drivers, f.Lambda, f.RMSE, f.R2, f.Spearman, f.Baseline)
+	}
+	rmse, r2, rho, base := r.CV()
+	fmt.Println(strings.Repeat("-", 72))
+	fmt.Printf("%-12s %-8d %-8s %-8.3f %-8.3f %-10.3f %-10.3f\n", "MEAN", r.Rows, "", rmse, r2, rho, base)
+
+	fmt.Println(strings.Repeat("-", 52))
+	fmt.Printf("%-8s %-10s %-10s %-12s %-8s\n", "TERM", "CURRENT", "FITTED", "PTS/UNIT", "FREE")
+	fmt.Println(strings.Repeat("-", 52))
+	for _, t := range r.Terms {
+		free := "yes"
+		if !t.Free {
+			free = "kept"
+		}
+		fmt.Printf("%-8s %-+10.4f %-+10.4f %-+12.4f %-8s\n", t.Term, t.Current, t.Fitted, t.Beta, free)
+	}
+	fmt.Println(strings.Repeat("-", 52))
+}
+
+// ============================================================
+//  WEIGHT PROFILES
+// ============================================================
+
+// F1WeightProfileV2 is a set of rawScore weights saved to disk, with the
+// fit quality of the calibration that produced it (CV* are nested
+// cross-validation means, out of sample for λ as well as the weights).
+type F1WeightProfileV2 struct {
+	Name       string `json:",omitempty"`
+	Rounds     int
+	Rows       int
+	Lambda     float64
+	CVRMSE     float64
+	CVR2       float64
+	CVSpearman float64
+	Weights    F1ScoreWeightsV2
+}
+
+// WriteWeightProfileV2 writes a profile as indented JSON.
+func WriteWeightProfileV2(path string, p F1WeightProfileV2) error {
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshaling weight profile: %v", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("error writing weight profile: %v", err)
+	}
+	return nil
+}
+
+// LoadWeightProfileV2 reads a profile. Weights missing from the file keep
+// their default value.
+func LoadWeightProfileV2(path string) (F1WeightProfileV2, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return F1WeightProfileV2{}, fmt.Errorf("error reading weight profile: %v", err)
+	}
+	p := F1WeightProfileV2{Weights: NewF1ScoreWeightsV2()}
+	if err := json.Unmarshal(data, &p); err != nil {
+		return F1WeightProfileV2{}, fmt.Errorf("error unmarshaling weight profile: %v", err)
+	}
+	if math.IsNaN(p.Weights.Bias) || math.IsInf(p.Weights.Bias, 0) {
+		return F1WeightProfileV2{}, fmt.Errorf("weight profile: Bias is not finite")
+	}
+	for _, t := range scoreTerms {
+		if w := *t.weight(&p.Weights); math.IsNaN(w) || math.IsInf(w, 0) {
+			return F1WeightProfileV2{}, fmt.Errorf("weight profile: %s is not finite", t.name)
+		}
+	}
+	return p, nil
+}
+
+// UseWeightProfile replaces the model's rawScore weights with the profile's.
+func (model *F1QuantumPricingModelV2) UseWeightProfile(p F1WeightProfileV2) {
+	model.Weights = p.Weights
+}
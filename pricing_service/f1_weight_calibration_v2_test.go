@@ -0,0 +1,158 @@
+package pricingservice
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFitRidgeRecoversCoefficients(t *testing.T) {
+	// y = 3 + 2·x0 − 0.5·x1, x2 constant
+	var rows []calibrationRow
+	for i := 0; i < 20; i++ {
+		x0, x1 := float64(i%5), float64((i*7)%11)
+		rows = append(rows, calibrationRow{x: []float64{x0, x1, 4}, y: 3 + 2*x0 - 0.5*x1})
+	}
+	fit, err := fitRidge(rows, 1e-9)
+	if err != nil {
+		t.Fatal(err)
+	}
+	near := func(a, b float64) bool { return math.Abs(a-b) < 1e-6 }
+	if !near(fit.beta[0], 2) || !near(fit.beta[1], -0.5) || !near(fit.intercept, 3) {
+		t.Errorf("fit = %v + %v, want 3 + [2 -0.5 0]", fit.intercept, fit.beta)
+	}
+	if fit.sd[2] != 0 || fit.beta[2] != 0 {
+		t.Errorf("constant feature fitted: sd %v β %v", fit.sd[2], fit.beta[2])
+	}
+
+	// a heavy penalty shrinks towards the mean
+	shrunk, _ := fitRidge(rows, 100)
+	if math.Abs(shrunk.beta[0]) >= 2*0.1 {
+		t.Errorf("β0 with λ 100 = %v, want heavily shrunk", shrunk.beta[0])
+	}
+}
+
+func TestAverageRanksAndSpearman(t *testing.T) {
+	got := averageRanks([]float64{10, 30, 20, 30})
+	want := []float64{1, 3.5, 2, 3.5}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("rank[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+	if rho := spearman([]float64{1, 2, 3}, []float64{10, 40, 90}); !approx(rho, 1) {
+		t.Errorf("monotone spearman = %v, want 1", rho)
+	}
+	if rho := spearman([]float64{1, 1, 1}, []float64{1, 2, 3}); rho != 0 {
+		t.Errorf("constant spearman = %v, want 0", rho)
+	}
+}
+
+func calibrationDriver(name, team string, ppr float64, finish int) F1BasicDriverDataV2 {
+	return F1BasicDriverDataV2{
+		Name: name, Team: team,
+		TeamData: F1TeamDataV2{Name: team, CurrentRace: 3, TotalRaces: 24},
+		Seasons: []F1BasicSeasonStatsV2{
+			{Year: 2025, Team: team, Races: 3, Points: 3 * ppr, TeamPoints: 6 * ppr, RecentRaces: []F1RaceResultV2{
+				race(1, finish, finish, ppr, true), race(2, finish+1, finish, ppr, true), race(3, finish, finish+1, ppr, true),
+			}},
+			{Year: 2024, Team: team, Races: 24, Points: 24 * ppr, TeamPoints: 40 * ppr},
+		},
+	}
+}
+
+func TestCalibrateWeights(t *testing.T) {
+	model := NewF1QuantumPricingModelV2()
+	model.Teams = testTeamRegistry(t, "Red", "Blue", "Green")
+	grid := []F1BasicDriverDataV2{
+		calibrationDriver("A", "Red", 18, 1),
+		calibrationDriver("B", "Red", 10, 4),
+		calibrationDriver("C", "Blue", 6, 7),
+		calibrationDriver("D", "Blue", 2, 12),
+		calibrationDriver("E", "Green", 1, 15),
+	}
+	var rounds []F1CalibrationRoundV2
+	for r := 4; r <= 6; r++ {
+		rounds = append(rounds, F1CalibrationRoundV2{
+			Season: 2025, Round: r, Drivers: grid,
+			Points: map[string]float64{"a": 25 + float64(r), "B": 15, "C": 8, "D": 2, "E": 0},
+		})
+	}
+
+	report, err := model.CalibrateWeights(rounds, NewF1CalibrationConfigV2())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Rows != 15 || len(report.Folds) != 3 {
+		t.Fatalf("%d rows / %d folds, want 15 / 3", report.Rows, len(report.Folds))
+	}
+	if len(report.Terms) != len(scoreTerms) {
+		t.Fatalf("%d terms, want %d", len(report.Terms), len(scoreTerms))
+	}
+	// each fold's λ is picked without the round it scores
+	rows, err := model.calibrationRows(rounds)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for f, fold := range report.Folds {
+		train, _ := splitFold(rows, f)
+		if l, _, _ := selectLambda(train, rounds, defaultCalibrationLambdas); fold.Lambda != l {
+			t.Errorf("fold %s λ = %v, want %v picked on the other rounds", fold.Round, fold.Lambda, l)
+		}
+	}
+	if _, _, rho, _ := report.CV(); rho < 0.9 {
+		t.Errorf("CV spearman = %v, want the strong ordering recovered", rho)
+	}
+
+	var l1Current, l1Fitted float64
+	for _, term := range report.Terms {
+		if !term.Free {
+			if term.Fitted != term.Current {
+				t.Errorf("%s did not vary but its weight changed", term.Term)
+			}
+			continue
+		}
+		l1Current += math.Abs(term.Current)
+		l1Fitted += math.Abs(term.Fitted)
+	}
+	if !approx(l1Current, l1Fitted) {
+		t.Errorf("fitted L1 = %v, want %v", l1Fitted, l1Current)
+	}
+	if model.Weights != NewF1ScoreWeightsV2() {
+		t.Errorf("CalibrateWeights changed the model's weights")
+	}
+	if _, err := model.CalibrateWeights(rounds[:2], NewF1CalibrationConfigV2()); err == nil {
+		t.Errorf("calibrated on 2 rounds, too few for nested cross-validation")
+	}
+}
+
+func TestWeightProfileRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	p := F1WeightProfileV2{Name: "fit", Weights: NewF1ScoreWeightsV2()}
+	p.Weights.PPR = 0.5
+	path := filepath.Join(dir, "weights.json")
+	if err := WriteWeightProfileV2(path, p); err != nil {
+		t.Fatal(err)
+	}
+	got, err := LoadWeightProfileV2(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Weights != p.Weights {
+		t.Errorf("round trip weights = %+v, want %+v", got.Weights, p.Weights)
+	}
+
+	// missing weights keep their defaults
+	partial := filepath.Join(dir, "partial.json")
+	if err := os.WriteFile(partial, []byte(`{"Weights": {"REC": 0.2}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	got, err = LoadWeightProfileV2(partial)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Weights.REC != 0.2 || got.Weights.PPR != wPPR || got.Weights.Bias != 0.15 {
+		t.Errorf("partial profile = %+v, want REC 0.2 and defaults elsewhere", got.Weights)
+	}
+}
@@ -0,0 +1,113 @@
+package pricingservice
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+// Golden price sheets: both models price the repo's f1_driver_data.json and
+// the full sheet is compared with testdata/*.golden. A model change shows
+// up as a golden diff; regenerate with
+//
+//	go test ./pricing_service -run Golden -update
+
+var update = flag.Bool("update", false, "rewrite golden files")
+
+const goldenDriverData = "../f1_driver_data.json"
+
+func loadGoldenDrivers[T any](t *testing.T) []T {
+	t.Helper()
+	data, err := os.ReadFile(goldenDriverData)
+	if err != nil {
+		t.Fatalf("error reading driver data: %v", err)
+	}
+	var out []T
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatalf("error unmarshaling driver data: %v", err)
+	}
+	return out
+}
+
+func checkGolden(t *testing.T, name string, got []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		if err := os.MkdirAll("testdata", 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("error reading golden file (run with -update to create it): %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		gl, wl := bytes.Split(got, []byte("\n")), bytes.Split(want, []byte("\n"))
+		for i := 0; i < max(len(gl), len(wl)); i++ {
+			var g, w []byte
+			if i < len(gl) {
+				g = gl[i]
+			}
+			if i < len(wl) {
+				w = wl[i]
+			}
+			if !bytes.Equal(g, w) {
+				t.Errorf("%s line %d:\n got: %s\nwant: %s", path, i+1, g, w)
+			}
+		}
+		t.Errorf("%s differs; rerun with -update if the change is intended", path)
+	}
+}
+
+func TestGoldenPriceSheetV1(t *testing.T) {
+	drivers := loadGoldenDrivers[F1BasicDriverData](t)
+	model := NewF1QuantumPricingModel(24, 10, 1000)
+	prices, err := model.ProcessAllDrivers(drivers)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	for _, p := range prices {
+		fmt.Fprintf(&buf, "%-20s %-16s %6.2f\n", p.Driver.BasicData.Name, p.Driver.BasicData.Team, p.Price)
+		keys := make([]string, 0, len(p.ComponentBreakdown))
+		for k := range p.ComponentBreakdown {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			fmt.Fprintf(&buf, "    %-28s %10.4f\n", k, p.ComponentBreakdown[k])
+		}
+	}
+	checkGolden(t, "v1_price_sheet", buf.Bytes())
+}
+
+func TestGoldenPriceSheetV2(t *testing.T) {
+	drivers := loadGoldenDrivers[F1BasicDriverDataV2](t)
+	model := NewF1QuantumPricingModelV2()
+	drvs := model.NewDriverSet(drivers)
+	teams := model.BuildTeamMapFromDrivers(drvs)
+	if err := model.PopulateDriverStats(drvs, teams); err != nil {
+		t.Fatal(err)
+	}
+	prices := model.PriceDrivers(drvs, 50, 2)
+
+	var buf bytes.Buffer
+	b := model.LastBand
+	fmt.Fprintf(&buf, "band %.4f – %.4f  lineup %.4f  binding %s\n", b.PMin, b.PMax, b.AchievedLineup, b.Binding)
+	for _, p := range prices {
+		d := p.Driver
+		fmt.Fprintf(&buf, "%-20s %-16s %6.2f  raw %8.4f  scaled %6.4f\n",
+			d.BasicData.Name, d.BasicData.Team, p.Price, d.RawScore, d.ScaledStrength)
+	}
+	checkGolden(t, "v2_price_sheet", buf.Bytes())
+}
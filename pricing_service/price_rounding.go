@@ -0,0 +1,230 @@
+package pricingservice
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+//
+// PRICE ROUNDING STRATEGIES (price presentation, shared by every model)
+//
+
+// PriceRoundingContext carries what a strategy may use besides the price.
+type PriceRoundingContext struct {
+	Driver       string
+	Team         string
+	Tier         string  // e.g. the team's budget tier
+	TeamStrength float64 // model-specific team strength (v1 anchoring)
+}
+
+// PriceRounder turns a model price into a presentable one.
+type PriceRounder interface {
+	Name() string
+	Round(price float64, ctx PriceRoundingContext) float64
+}
+
+// TickRounding rounds to a multiple of Tick: "up", "down" or "nearest".
+type TickRounding struct {
+	Tick float64
+	Mode string
+}
+
+func (r TickRounding) Name() string { return fmt.Sprintf("tick-%g-%s", r.Tick, r.Mode) }
+func (r TickRounding) Round(price float64, _ PriceRoundingContext) float64 {
+	if r.Tick <= 0 {
+		return price
+	}
+	// snap away float noise (e.g. 20.000000001) before ceil / floor
+	q := math.Round(price/r.Tick*1e6) / 1e6
+	switch r.Mode {
+	case "up":
+		q = math.Ceil(q)
+	case "down":
+		q = math.Floor(q)
+	default:
+		q = math.Round(q)
+	}
+	return q * r.Tick
+}
+
+// NineEndingRounding rounds to the nearest 0.1 M, then turns a .0 ending
+// into the .9 below it and a .6 ending into .4 (19.9 reads cheaper than 20.0).
+type NineEndingRounding struct{}
+
+func (NineEndingRounding) Name() string { return "nine-endings" }
+func (NineEndingRounding) Round(price float64, _ PriceRoundingContext) float64 {
+	tenths := int(math.Round(price * 10))
+	switch tenths % 10 {
+	case 0:
+		if tenths > 0 {
+			tenths--
+		}
+	case 6:
+		tenths -= 2
+	}
+	return float64(tenths) / 10
+}
+
+// TeamAnchorRounding is v1's team-based psychological anchoring: top teams
+// end in .9 above TopPrice (.5 below), midfield in .5 above MidPrice (whole
+// millions below), everyone else on the 0.5 grid below the price.
+type TeamAnchorRounding struct {
+	TopStrength, MidStrength float64 // team-strength thresholds
+	TopPrice, MidPrice       float64
+}
+
+func (TeamAnchorRounding) Name() string { return "team-anchor" }
+func (r TeamAnchorRounding) Round(price float64, ctx PriceRoundingContext) float64 {
+	if ctx.TeamStrength > r.TopStrength { // Top teams
+		if price > r.TopPrice {
+			return math.Floor(price) + 0.9
+		}
+		return math.Floor(price) + 0.5
+	} else if ctx.TeamStrength > r.MidStrength { // Midfield teams
+		if price > r.MidPrice {
+			return math.Floor(price) + 0.5
+		}
+		return math.Floor(price)
+	}
+	return math.Floor(price*2) / 2 // Backmarker teams: round to 0.5
+}
+
+// TierAnchorRounding ends every price on a per-tier decimal (e.g. Top ⇒ .9,
+// Backmarker ⇒ .5); tiers not listed use Default.
+type TierAnchorRounding struct {
+	Endings map[string]float64 // keyed by lower-case tier
+	Default float64
+}
+
+func (TierAnchorRounding) Name() string { return "tier-anchor" }
+func (r TierAnchorRounding) Round(price float64, ctx PriceRoundingContext) float64 {
+	end, ok := r.Endings[strings.ToLower(ctx.Tier)]
+	if !ok {
+		end = r.Default
+	}
+	return math.Floor(price) + end
+}
+
+// NewPriceRounder builds a named strategy with its default parameters.
+func NewPriceRounder(name string) (PriceRounder, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "half-up":
+		return TickRounding{Tick: 0.5, Mode: "up"}, nil
+	case "tick-0.1":
+		return TickRounding{Tick: 0.1, Mode: "nearest"}, nil
+	case "nine-endings":
+		return NineEndingRounding{}, nil
+	case "team-anchor":
+		return TeamAnchorRounding{TopStrength: 1.3, MidStrength: 1.0, TopPrice: 20, MidPrice: 15}, nil
+	case "tier-anchor":
+		return TierAnchorRounding{
+			Endings: map[string]float64{"top": 0.9, "upper-mid": 0.5, "lower-mid": 0.5, "backmarker": 0.0},
+			Default: 0.5,
+		}, nil
+	case "none":
+		return TickRounding{}, nil
+	}
+	return nil, fmt.Errorf("unknown rounding strategy %q", name)
+}
+
+// PriceRounding is the presentation config a model applies to its prices.
+type PriceRounding struct {
+	Strategy          PriceRounder
+	DistinctTeammates bool    // never publish two teammates at the same price
+	TeammateTick      float64 // step used to separate teammates
+}
+
+// default strategy per "sport/model"
+var defaultRoundingStrategies = map[string]string{
+	"f1/v1":        "team-anchor",
+	"f1/v2":        "half-up",
+	"motogp/v1":    "tick-0.1",
+	"formulae/v1":  "tick-0.1",
+	"formula-e/v1": "tick-0.1",
+}
+
+// DefaultPriceRounding returns the presentation defaults for a sport and model.
+func DefaultPriceRounding(sport, model string) PriceRounding {
+	name, ok := defaultRoundingStrategies[strings.ToLower(sport+"/"+model)]
+	if !ok {
+		name = "tick-0.1"
+	}
+	r, _ := NewPriceRounder(name)
+	return PriceRounding{Strategy: r, TeammateTick: 0.1}
+}
+
+// Round applies the strategy; a zero-value config leaves prices untouched.
+func (p PriceRounding) Round(price float64, ctx PriceRoundingContext) float64 {
+	if p.Strategy == nil {
+		return price
+	}
+	return p.Strategy.Round(price, ctx)
+}
+
+// Name describes the configured presentation.
+func (p PriceRounding) Name() string {
+	name := "none"
+	if p.Strategy != nil {
+		name = p.Strategy.Name()
+	}
+	if p.DistinctTeammates {
+		name += "+distinct-teammates"
+	}
+	return name
+}
+
+// SeparateTeammates nudges prices so no two drivers of the same team share a
+// price: within a team (team returns its registry ID), a tie moves the
+// weaker driver to the nearest untied price TeammateTick steps below, or
+// above when allowed (nil = any price) rules every lower one out. A driver
+// who may not move at all keeps the price and the teammate moves instead.
+// It is a no-op unless DistinctTeammates is set.
+func (p PriceRounding) SeparateTeammates(n int, team func(int) string, strength func(int) float64,
+	allowed func(int, float64) bool, get func(int) float64, set func(int, float64)) {
+	if !p.DistinctTeammates || p.TeammateTick <= 0 {
+		return
+	}
+	ok := func(i int, price float64) bool { return allowed == nil || allowed(i, price) }
+	pinned := func(i int) bool {
+		return !ok(i, get(i)-p.TeammateTick) && !ok(i, get(i)+p.TeammateTick)
+	}
+	byTeam := map[string][]int{}
+	for i := 0; i < n; i++ {
+		byTeam[team(i)] = append(byTeam[team(i)], i)
+	}
+	for _, idx := range byTeam {
+		// pinned drivers first, then the stronger ones: each driver only
+		// moves away from the teammates ahead of it
+		sort.SliceStable(idx, func(a, b int) bool {
+			if pa, pb := pinned(idx[a]), pinned(idx[b]); pa != pb {
+				return pa
+			}
+			return strength(idx[a]) > strength(idx[b])
+		})
+		for k := 1; k < len(idx); k++ {
+			tied := func(price float64) bool {
+				for _, j := range idx[:k] {
+					if math.Abs(price-get(j)) < 1e-9 {
+						return true
+					}
+				}
+				return false
+			}
+			i := idx[k]
+			if !tied(get(i)) {
+				continue
+			}
+		search:
+			for _, dir := range []float64{-1, 1} {
+				for step := 1; step <= len(idx); step++ {
+					if price := get(i) + dir*float64(step)*p.TeammateTick; !tied(price) && ok(i, price) {
+						set(i, price)
+						break search
+					}
+				}
+			}
+		}
+	}
+}
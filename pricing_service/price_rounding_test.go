@@ -0,0 +1,165 @@
+package pricingservice
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestPriceRounders(t *testing.T) {
+	tier := func(name string) PriceRoundingContext { return PriceRoundingContext{Tier: name} }
+	tests := []struct {
+		strategy string
+		in       float64
+		ctx      PriceRoundingContext
+		want     float64
+	}{
+		{"half-up", 10.01, PriceRoundingContext{}, 10.5},
+		{"half-up", 20.000000001, PriceRoundingContext{}, 20},
+		{"tick-0.1", 10.04, PriceRoundingContext{}, 10.0},
+		{"tick-0.1", 10.05, PriceRoundingContext{}, 10.1},
+		{"nine-endings", 20.02, PriceRoundingContext{}, 19.9},
+		{"nine-endings", 15.6, PriceRoundingContext{}, 15.4},
+		{"nine-endings", 15.3, PriceRoundingContext{}, 15.3},
+		{"tier-anchor", 20.2, tier("Top"), 20.9},
+		{"tier-anchor", 8.7, tier("Backmarker"), 8},
+		{"tier-anchor", 8.7, tier("Factory"), 8.5},
+		{"team-anchor", 22.3, PriceRoundingContext{TeamStrength: 1.5}, 22.9},
+		{"team-anchor", 12.3, PriceRoundingContext{TeamStrength: 1.1}, 12},
+		{"team-anchor", 7.8, PriceRoundingContext{}, 7.5},
+		{"none", 12.345, PriceRoundingContext{}, 12.345},
+	}
+	for _, tt := range tests {
+		r, err := NewPriceRounder(tt.strategy)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := r.Round(tt.in, tt.ctx); math.Abs(got-tt.want) > eps {
+			t.Errorf("%s(%v, %+v) = %v, want %v", tt.strategy, tt.in, tt.ctx, got, tt.want)
+		}
+	}
+	if _, err := NewPriceRounder("psychic"); err == nil {
+		t.Errorf("an unknown strategy was accepted")
+	}
+	if got := (PriceRounding{}).Round(12.345, PriceRoundingContext{}); got != 12.345 {
+		t.Errorf("zero-value rounding = %v", got)
+	}
+}
+
+// separate runs SeparateTeammates over one team.
+func separate(p PriceRounding, prices, strength []float64, allowed func(int, float64) bool) []float64 {
+	out := append([]float64(nil), prices...)
+	p.SeparateTeammates(len(out),
+		func(int) string { return "team" },
+		func(i int) float64 { return strength[i] },
+		allowed,
+		func(i int) float64 { return out[i] },
+		func(i int, v float64) { out[i] = v })
+	return out
+}
+
+func TestSeparateTeammates(t *testing.T) {
+	p := PriceRounding{DistinctTeammates: true, TeammateTick: 0.1}
+	near := func(got, want []float64) bool {
+		for i := range got {
+			if math.Abs(got[i]-want[i]) > eps {
+				return false
+			}
+		}
+		return true
+	}
+
+	// the weaker drivers step down below the stronger one
+	if got := separate(p, []float64{10, 10, 10}, []float64{0.2, 0.9, 0.5}, nil); !near(got, []float64{9.8, 10, 9.9}) {
+		t.Errorf("three-way tie = %v", got)
+	}
+	if got := separate(PriceRounding{TeammateTick: 0.1}, []float64{10, 10}, []float64{1, 0}, nil); !near(got, []float64{10, 10}) {
+		t.Errorf("separated without DistinctTeammates: %v", got)
+	}
+
+	// a floor at 10 rules the lower prices out: the weaker driver steps up
+	floor := func(_ int, v float64) bool { return v >= 10-eps }
+	if got := separate(p, []float64{10, 10}, []float64{1, 0}, floor); !near(got, []float64{10, 10.1}) {
+		t.Errorf("tie on the floor = %v", got)
+	}
+
+	// a pinned weaker driver keeps the price and the stronger one moves
+	pinned := func(i int, v float64) bool { return i != 1 || v == 10 }
+	if got := separate(p, []float64{10, 10}, []float64{1, 0}, pinned); !near(got, []float64{9.9, 10}) {
+		t.Errorf("tie with a pinned teammate = %v", got)
+	}
+	never := func(int, float64) bool { return false }
+	if got := separate(p, []float64{10, 10}, []float64{1, 0}, never); !near(got, []float64{10, 10}) {
+		t.Errorf("two pinned teammates moved: %v", got)
+	}
+}
+
+// rulesGrid prices the golden grid from a previous sheet of 10 M for everyone.
+func rulesGrid(t *testing.T, rules *PriceRuleSet) (*F1QuantumPricingModelV2, []F1DriverPriceV2) {
+	t.Helper()
+	model := NewF1QuantumPricingModelV2()
+	model.Rounding.DistinctTeammates = true
+	model.Rounding.TeammateTick = 0.1
+	drvs := model.NewDriverSet(loadGoldenDrivers[F1BasicDriverDataV2](t))
+	teams := model.BuildTeamMapFromDrivers(drvs)
+	if err := model.PopulateDriverStats(drvs, teams); err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range drvs {
+		d.Price = 10
+	}
+	if rules != nil && rules.FreezeRounds != nil {
+		rules.FreezeRounds = []int{model.pricingRound(drvs)}
+	}
+	model.Rules = rules
+	return model, model.PriceDrivers(drvs, 50, 2)
+}
+
+func TestPriceDriversSeparatesAfterRules(t *testing.T) {
+	model, prices := rulesGrid(t, &PriceRuleSet{MaxChangeAbs: 0.5})
+	seen := map[string]string{}
+	var spend float64
+	separated := 0
+	for _, p := range prices {
+		name := p.Driver.BasicData.Name
+		if p.Price < 9.5-eps || p.Price > 10.5+eps {
+			t.Errorf("%s: %v breaks the ±0.5 cap", name, p.Price)
+		}
+		key := fmt.Sprintf("%s@%.4f", p.Driver.TeamID, p.Price)
+		if other, ok := seen[key]; ok {
+			t.Errorf("%s and %s share %v after the cap", name, other, p.Price)
+		}
+		seen[key] = name
+		if got := p.ComponentBreakdown["Final Price"]; got != p.Price {
+			t.Errorf("%s: Final Price %v, price %v", name, got, p.Price)
+		}
+		for _, e := range p.RuleLog {
+			if e.Rule == "distinct-teammates" {
+				separated++
+			}
+		}
+		spend += p.Price
+	}
+	if separated == 0 {
+		t.Errorf("no capped teammates were separated; the grid no longer exercises the cap")
+	}
+	if want := 2 * spend / float64(len(prices)); !approx(model.LastBand.AchievedLineup, want) {
+		t.Errorf("AchievedLineup = %v, want the shipped %v", model.LastBand.AchievedLineup, want)
+	}
+	if !approx(model.LastBand.Slack, model.LastBand.TargetLineup-model.LastBand.AchievedLineup) {
+		t.Errorf("Slack %v does not match the shipped lineup", model.LastBand.Slack)
+	}
+
+	// a frozen round pins every price: nothing is nudged off the freeze
+	_, frozen := rulesGrid(t, &PriceRuleSet{FreezeRounds: []int{}})
+	for _, p := range frozen {
+		if p.Price != 10 {
+			t.Errorf("%s: frozen price moved to %v", p.Driver.BasicData.Name, p.Price)
+		}
+		for _, e := range p.RuleLog {
+			if e.Rule == "distinct-teammates" {
+				t.Errorf("%s: separated during a freeze", p.Driver.BasicData.Name)
+			}
+		}
+	}
+}
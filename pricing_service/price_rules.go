@@ -0,0 +1,184 @@
+package pricingservice
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"slices"
+	"strings"
+)
+
+//
+// PRICE-CHANGE RULES ENGINE (sport-agnostic, used by every pricing model)
+//
+
+// Rules run after a model has produced its price, in this order:
+//
+//  1. manual override  – an active override replaces the price outright
+//  2. freeze           – during a frozen round the previous price stands
+//  3. change cap       – move per round limited by MaxChangeAbs / MaxChangePct
+//  4. tier bounds      – floor / ceiling for the driver's tier
+//
+// Rounds always refer to the round the price sheet is published for.
+// Every rule that changes a price leaves a PriceRuleLogEntry behind.
+
+// PriceTierBound limits the price range of one tier (e.g. a team budget tier).
+type PriceTierBound struct {
+	Tier    string
+	Floor   float64 // 0 = no floor
+	Ceiling float64 // 0 = no ceiling
+}
+
+// PriceRuleOverride pins a driver's price for a window of rounds.
+type PriceRuleOverride struct {
+	Driver     string
+	Price      float64
+	FromRound  int
+	UntilRound int // inclusive; the override expires after this round (0 = open-ended)
+	Reason     string
+}
+
+// PriceRuleSet holds the business rules applied on top of a model price.
+type PriceRuleSet struct {
+	MaxChangeAbs float64 // max move per round in M (0 = unlimited)
+	MaxChangePct float64 // max move per round as a fraction of the previous price (0 = unlimited)
+	Tiers        []PriceTierBound
+	FreezeRounds []int // race weekends during which prices don't move
+	Overrides    []PriceRuleOverride
+}
+
+// PriceRuleInput is one driver's price as handed over by a model.
+type PriceRuleInput struct {
+	Driver   string
+	Tier     string
+	Round    int
+	Previous float64 // last published price, 0 if none
+	Proposed float64 // model price
+}
+
+// PriceRuleLogEntry explains which rule moved a price and by how much.
+type PriceRuleLogEntry struct {
+	Driver string
+	Round  int
+	Rule   string
+	Before float64
+	After  float64
+	Note   string
+	Author string `json:",omitempty"` // set for manual / editorial entries
+}
+
+// LoadPriceRuleSet reads a rule set from a JSON file.
+func LoadPriceRuleSet(path string) (*PriceRuleSet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading price rules file: %v", err)
+	}
+	var rs PriceRuleSet
+	if err := json.Unmarshal(data, &rs); err != nil {
+		return nil, fmt.Errorf("error unmarshaling price rules: %v", err)
+	}
+	return &rs, nil
+}
+
+// activeOverride returns the override in force for a driver at a round.
+func (rs *PriceRuleSet) activeOverride(driver string, round int) (PriceRuleOverride, bool) {
+	for _, o := range rs.Overrides {
+		if !strings.EqualFold(o.Driver, driver) || round < o.FromRound {
+			continue
+		}
+		if o.UntilRound != 0 && round > o.UntilRound {
+			continue
+		}
+		return o, true
+	}
+	return PriceRuleOverride{}, false
+}
+
+func (rs *PriceRuleSet) tierBound(tier string) (PriceTierBound, bool) {
+	for _, t := range rs.Tiers {
+		if strings.EqualFold(t.Tier, tier) {
+			return t, true
+		}
+	}
+	return PriceTierBound{}, false
+}
+
+// Apply runs every rule on a proposed price and returns the final price plus
+// a log of the rules that changed it. A nil rule set passes prices through.
+func (rs *PriceRuleSet) Apply(in PriceRuleInput) (float64, []PriceRuleLogEntry) {
+	price := in.Proposed
+	if rs == nil {
+		return price, nil
+	}
+	var log []PriceRuleLogEntry
+	set := func(rule string, next float64, note string) {
+		if next == price {
+			return
+		}
+		log = append(log, PriceRuleLogEntry{
+			Driver: in.Driver, Round: in.Round, Rule: rule,
+			Before: price, After: next, Note: note,
+		})
+		price = next
+	}
+
+	// 1. manual override
+	if o, ok := rs.activeOverride(in.Driver, in.Round); ok {
+		note := o.Reason
+		if o.UntilRound != 0 {
+			note = fmt.Sprintf("%s (until round %d)", o.Reason, o.UntilRound)
+		}
+		set("override", o.Price, note)
+		return price, log
+	}
+
+	// nothing to compare against on a first publication
+	if in.Previous > 0 {
+		// 2. freeze window
+		if slices.Contains(rs.FreezeRounds, in.Round) {
+			set("freeze", in.Previous, fmt.Sprintf("round %d is frozen", in.Round))
+			return price, log
+		}
+
+		// 3. per-round change cap (tighter of absolute and percentage)
+		limit := math.Inf(1)
+		if rs.MaxChangeAbs > 0 {
+			limit = rs.MaxChangeAbs
+		}
+		if rs.MaxChangePct > 0 {
+			limit = math.Min(limit, rs.MaxChangePct*in.Previous)
+		}
+		if move := price - in.Previous; math.Abs(move) > limit {
+			set("max-change", in.Previous+math.Copysign(limit, move),
+				fmt.Sprintf("move %+.2f capped at ±%.2f", move, limit))
+		}
+	}
+
+	// 4. tier floor / ceiling
+	if t, ok := rs.tierBound(in.Tier); ok {
+		if t.Floor > 0 && price < t.Floor {
+			set("tier-floor", t.Floor, fmt.Sprintf("%s floor %.2f", t.Tier, t.Floor))
+		}
+		if t.Ceiling > 0 && price > t.Ceiling {
+			set("tier-ceiling", t.Ceiling, fmt.Sprintf("%s ceiling %.2f", t.Tier, t.Ceiling))
+		}
+	}
+	return price, log
+}
+
+// PrintRuleLog prints every rule that changed a price.
+func PrintRuleLog(entries []PriceRuleLogEntry) {
+	fmt.Println("\n=== PRICE RULE LOG ===")
+	fmt.Println(strings.Repeat("-", 100))
+	fmt.Printf("%-20s %-6s %-18s %-9s %-9s %s\n", "DRIVER", "ROUND", "RULE", "BEFORE", "AFTER", "NOTE")
+	fmt.Println(strings.Repeat("-", 100))
+	for _, e := range entries {
+		note := e.Note
+		if e.Author != "" {
+			note = fmt.Sprintf("%s [%s]", note, e.Author)
+		}
+		fmt.Printf("%-20s %-6d %-18s %-9.2f %-9.2f %s\n", e.Driver, e.Round, e.Rule, e.Before, e.After, note)
+	}
+	fmt.Println(strings.Repeat("-", 100))
+}
@@ -0,0 +1,58 @@
+package pricingservice
+
+import (
+	"strings"
+	"testing"
+)
+
+func ruleNames(log []PriceRuleLogEntry) string {
+	var names []string
+	for _, e := range log {
+		names = append(names, e.Rule)
+	}
+	return strings.Join(names, ",")
+}
+
+func TestPriceRulesOrder(t *testing.T) {
+	rs := &PriceRuleSet{
+		MaxChangeAbs: 1.5,
+		MaxChangePct: 0.10,
+		Tiers:        []PriceTierBound{{Tier: "Backmarker", Floor: 9.5, Ceiling: 12}},
+		FreezeRounds: []int{8},
+		Overrides:    []PriceRuleOverride{{Driver: "Albon", Price: 30, FromRound: 7, UntilRound: 8, Reason: "manual"}},
+	}
+	tests := []struct {
+		name  string
+		in    PriceRuleInput
+		want  float64
+		rules string
+	}{
+		// an override beats the freeze, the cap and the tier ceiling
+		{"override", PriceRuleInput{Driver: "albon", Tier: "Backmarker", Round: 8, Previous: 10, Proposed: 11}, 30, "override"},
+		{"override expired", PriceRuleInput{Driver: "Albon", Tier: "Backmarker", Round: 9, Previous: 10, Proposed: 10.5}, 10.5, ""},
+		// a freeze beats the cap and the tier bounds
+		{"freeze", PriceRuleInput{Driver: "Sainz", Tier: "Backmarker", Round: 8, Previous: 13, Proposed: 8}, 13, "freeze"},
+		{"first publication not frozen", PriceRuleInput{Driver: "Sainz", Tier: "Top", Round: 8, Proposed: 8}, 8, ""},
+		// the tighter cap (10% of 10 = 1) applies, then the tier floor lifts it
+		{"cap then floor", PriceRuleInput{Driver: "Sainz", Tier: "Backmarker", Round: 9, Previous: 10, Proposed: 5}, 9.5, "max-change,tier-floor"},
+		{"cap only", PriceRuleInput{Driver: "Sainz", Tier: "Top", Round: 9, Previous: 10, Proposed: 13}, 11, "max-change"},
+		{"ceiling", PriceRuleInput{Driver: "Sainz", Tier: "backmarker", Round: 9, Previous: 11.8, Proposed: 12.5}, 12, "tier-ceiling"},
+		{"untouched", PriceRuleInput{Driver: "Sainz", Tier: "Top", Round: 9, Previous: 10, Proposed: 10.4}, 10.4, ""},
+	}
+	for _, tt := range tests {
+		got, log := rs.Apply(tt.in)
+		if !approx(got, tt.want) || ruleNames(log) != tt.rules {
+			t.Errorf("%s: %v by [%s], want %v by [%s]", tt.name, got, ruleNames(log), tt.want, tt.rules)
+		}
+		for i, e := range log {
+			if i > 0 && e.Before != log[i-1].After {
+				t.Errorf("%s: log entry %d starts at %v, previous ended at %v", tt.name, i, e.Before, log[i-1].After)
+			}
+		}
+	}
+
+	var none *PriceRuleSet
+	if got, log := none.Apply(PriceRuleInput{Proposed: 7}); got != 7 || log != nil {
+		t.Errorf("nil rule set = %v, %v", got, log)
+	}
+}
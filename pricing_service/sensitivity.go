@@ -0,0 +1,309 @@
+package pricingservice
+
+import (
+	"encoding/csv"
+	"fmt"
+	"math"
+	"os"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//
+// WEIGHT SENSITIVITY ANALYSIS (shared by every model)
+//
+
+// Each numeric model parameter is scaled down and up by a relative step
+// (default ±10 %), the grid is repriced, and the effect on every driver's
+// price and rank and on the grid's total spend is recorded against the
+// unperturbed sheet. Parameters that are 0 cannot be scaled and are skipped.
+// Results are ordered by swing (mean |high − low| price over the grid),
+// which is the bar order of a tornado chart.
+
+const defaultSensitivityStep = 0.10
+
+// SensitivityConfig sets how far each parameter is perturbed.
+type SensitivityConfig struct {
+	Step  float64            // relative step applied down and up (0.10 = ±10 %)
+	Steps map[string]float64 // per-parameter override, e.g. "Weights.PPR": 0.25
+}
+
+func NewSensitivityConfig() SensitivityConfig {
+	return SensitivityConfig{Step: defaultSensitivityStep}
+}
+
+func (c SensitivityConfig) step(param string) float64 {
+	if s, ok := c.Steps[param]; ok {
+		return s
+	}
+	if c.Step > 0 {
+		return c.Step
+	}
+	return defaultSensitivityStep
+}
+
+// SensitivityPrice is one driver's price on a repriced sheet.
+type SensitivityPrice struct {
+	Driver string
+	Price  float64
+}
+
+// SensitivityDriverEffect is one driver's price and rank under the low and
+// high perturbation of a parameter.
+type SensitivityDriverEffect struct {
+	Driver                         string
+	BasePrice, LowPrice, HighPrice float64
+	BaseRank, LowRank, HighRank    int
+}
+
+// SensitivityResult is the effect of perturbing one parameter.
+type SensitivityResult struct {
+	Parameter                      string
+	Base, Low, High                float64 // parameter values
+	BaseSpend, LowSpend, HighSpend float64 // sum of all prices on the sheet
+	Drivers                        []SensitivityDriverEffect
+}
+
+// Swing is the mean |high − low| price change over the grid.
+func (r SensitivityResult) Swing() float64 {
+	if len(r.Drivers) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, e := range r.Drivers {
+		sum += math.Abs(e.HighPrice - e.LowPrice)
+	}
+	return sum / float64(len(r.Drivers))
+}
+
+// MaxMove returns the driver whose price moves most between low and high.
+func (r SensitivityResult) MaxMove() (driver string, move float64) {
+	for _, e := range r.Drivers {
+		if m := math.Abs(e.HighPrice - e.LowPrice); m > move {
+			driver, move = e.Driver, m
+		}
+	}
+	return driver, move
+}
+
+// RankMoves counts drivers whose rank changes under either perturbation.
+func (r SensitivityResult) RankMoves() int {
+	n := 0
+	for _, e := range r.Drivers {
+		if e.LowRank != e.BaseRank || e.HighRank != e.BaseRank {
+			n++
+		}
+	}
+	return n
+}
+
+// SensitivityReport collects the results of one sensitivity run.
+type SensitivityReport struct {
+	Model   string
+	Results []SensitivityResult
+}
+
+// sensitivityParam is one perturbable parameter of a model.
+type sensitivityParam struct {
+	name  string
+	value *float64
+}
+
+// sensitivityGroup names a config struct whose float64 fields are parameters.
+type sensitivityGroup struct {
+	name string
+	ptr  any      // pointer to the struct
+	skip []string // fields that are not pricing knobs
+}
+
+func sensitivityParams(groups ...sensitivityGroup) []sensitivityParam {
+	var out []sensitivityParam
+	for _, g := range groups {
+		v := reflect.ValueOf(g.ptr).Elem()
+		for i := 0; i < v.NumField(); i++ {
+			f, field := v.Field(i), v.Type().Field(i)
+			if f.Kind() != reflect.Float64 || !field.IsExported() {
+				continue
+			}
+			if slices.Contains(g.skip, field.Name) {
+				continue
+			}
+			out = append(out, sensitivityParam{name: g.name + "." + field.Name, value: f.Addr().Interface().(*float64)})
+		}
+	}
+	return out
+}
+
+// runSensitivity perturbs each parameter in place, calling price for the
+// repriced sheet, and restores it afterwards.
+func runSensitivity(model string, params []sensitivityParam, cfg SensitivityConfig, price func() []SensitivityPrice) SensitivityReport {
+	base := price()
+	baseRank := priceRanks(base)
+	report := SensitivityReport{Model: model}
+	for _, p := range params {
+		orig := *p.value
+		if orig == 0 {
+			continue
+		}
+		step := cfg.step(p.name)
+		*p.value = orig * (1 - step)
+		low := price()
+		*p.value = orig * (1 + step)
+		high := price()
+		*p.value = orig
+
+		lowRank, highRank := priceRanks(low), priceRanks(high)
+		res := SensitivityResult{
+			Parameter: p.name,
+			Base:      orig, Low: orig * (1 - step), High: orig * (1 + step),
+			BaseSpend: sheetSpend(base), LowSpend: sheetSpend(low), HighSpend: sheetSpend(high),
+		}
+		for i, b := range base {
+			res.Drivers = append(res.Drivers, SensitivityDriverEffect{
+				Driver:    b.Driver,
+				BasePrice: b.Price, LowPrice: low[i].Price, HighPrice: high[i].Price,
+				BaseRank: baseRank[i], LowRank: lowRank[i], HighRank: highRank[i],
+			})
+		}
+		report.Results = append(report.Results, res)
+	}
+	sort.SliceStable(report.Results, func(i, j int) bool {
+		return report.Results[i].Swing() > report.Results[j].Swing()
+	})
+	return report
+}
+
+// priceRanks ranks a sheet by price, most expensive = 1 (ties keep sheet order).
+func priceRanks(sheet []SensitivityPrice) []int {
+	idx := make([]int, len(sheet))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(a, b int) bool { return sheet[idx[a]].Price > sheet[idx[b]].Price })
+	ranks := make([]int, len(sheet))
+	for r, i := range idx {
+		ranks[i] = r + 1
+	}
+	return ranks
+}
+
+func sheetSpend(sheet []SensitivityPrice) float64 {
+	var sum float64
+	for _, p := range sheet {
+		sum += p.Price
+	}
+	return sum
+}
+
+// PrintSensitivityTable prints one row per parameter, largest swing first.
+func PrintSensitivityTable(r SensitivityReport) {
+	fmt.Printf("\n=== WEIGHT SENSITIVITY (%s) ===\n", strings.ToUpper(r.Model))
+	fmt.Println(strings.Repeat("-", 120))
+	fmt.Printf("%-26s %-9s %-9s %-9s %-8s %-28s %-6s %-10s %-10s\n",
+		"PARAMETER", "BASE", "LOW", "HIGH", "SWING", "BIGGEST MOVER", "RANKS", "SPEND LOW", "SPEND HIGH")
+	fmt.Println(strings.Repeat("-", 120))
+	for _, res := range r.Results {
+		driver, move := res.MaxMove()
+		mover := "-"
+		if driver != "" {
+			mover = fmt.Sprintf("%s (%.2f)", driver, move)
+		}
+		fmt.Printf("%-26s %-9.4f %-9.4f %-9.4f %-8.3f %-28s %-6d %-+10.2f %-+10.2f\n",
+			res.Parameter, res.Base, res.Low, res.High, res.Swing(), mover, res.RankMoves(),
+			res.LowSpend-res.BaseSpend, res.HighSpend-res.BaseSpend)
+	}
+	fmt.Println(strings.Repeat("-", 120))
+}
+
+// WriteSensitivityCSV writes the report in long format, one row per
+// parameter and driver plus a "(grid spend)" row per parameter, in tornado
+// order. Filter on driver to chart one driver or the grid spend.
+func WriteSensitivityCSV(path string, r SensitivityReport) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("error creating sensitivity CSV: %v", err)
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	ff := func(x float64) string { return strconv.FormatFloat(x, 'f', 4, 64) }
+	fi := strconv.Itoa
+	rows := [][]string{{
+		"model", "parameter", "base_value", "low_value", "high_value", "swing",
+		"driver", "base_price", "low_price", "high_price", "low_change", "high_change",
+		"base_rank", "low_rank", "high_rank",
+	}}
+	for _, res := range r.Results {
+		head := []string{r.Model, res.Parameter, ff(res.Base), ff(res.Low), ff(res.High), ff(res.Swing())}
+		for _, e := range res.Drivers {
+			rows = append(rows, append(slices.Clone(head),
+				e.Driver, ff(e.BasePrice), ff(e.LowPrice), ff(e.HighPrice),
+				ff(e.LowPrice-e.BasePrice), ff(e.HighPrice-e.BasePrice),
+				fi(e.BaseRank), fi(e.LowRank), fi(e.HighRank)))
+		}
+		rows = append(rows, append(slices.Clone(head),
+			"(grid spend)", ff(res.BaseSpend), ff(res.LowSpend), ff(res.HighSpend),
+			ff(res.LowSpend-res.BaseSpend), ff(res.HighSpend-res.BaseSpend), "", "", ""))
+	}
+	if err := w.WriteAll(rows); err != nil {
+		return fmt.Errorf("error writing sensitivity CSV: %v", err)
+	}
+	return nil
+}
+
+// Sensitivity perturbs the rawScore weights, elasticity, ownership demand
+// and budget band of the v2 model and reprices drvs (already through
+// PopulateDriverStats) for each perturbation. Every sheet is priced from
+// the drivers' CurrentPrice, as a fresh run would be; their published
+// prices are restored afterwards.
+func (model *F1QuantumPricingModelV2) Sensitivity(drvs []*F1CompleteDriverV2, cap float64, roster int, cfg SensitivityConfig) SensitivityReport {
+	published := make([]float64, len(drvs))
+	for i, d := range drvs {
+		published[i] = d.Price
+	}
+	lastBand := model.LastBand
+	defer func() {
+		for i, d := range drvs {
+			d.Price = published[i]
+		}
+		model.LastBand = lastBand
+	}()
+
+	params := sensitivityParams(
+		sensitivityGroup{name: "Weights", ptr: &model.Weights},
+		sensitivityGroup{name: "Elasticity", ptr: &model.Elasticity},
+		sensitivityGroup{name: "Demand", ptr: &model.Demand},
+		sensitivityGroup{name: "Band", ptr: &model.Band, skip: []string{"Tolerance"}},
+	)
+	return runSensitivity("v2", params, cfg, func() []SensitivityPrice {
+		for _, d := range drvs {
+			d.Price = d.BasicData.CurrentPrice
+		}
+		prices := model.PriceDrivers(drvs, cap, roster)
+		out := make([]SensitivityPrice, len(prices))
+		for i, p := range prices {
+			out[i] = SensitivityPrice{Driver: p.Driver.BasicData.Name, Price: p.Price}
+		}
+		return out
+	})
+}
+
+// Sensitivity perturbs the v1 base price and premium multipliers and
+// reprices the drivers for each perturbation.
+func (m *F1QuantumPricingModel) Sensitivity(drivers []F1BasicDriverData, cfg SensitivityConfig) (SensitivityReport, error) {
+	if err := m.checkTeams(drivers); err != nil {
+		return SensitivityReport{}, err
+	}
+	params := sensitivityParams(sensitivityGroup{name: "Premiums", ptr: &m.Premiums})
+	return runSensitivity("v1", params, cfg, func() []SensitivityPrice {
+		prices, _ := m.ProcessAllDrivers(drivers) // teams checked above
+		out := make([]SensitivityPrice, len(prices))
+		for i, p := range prices {
+			out[i] = SensitivityPrice{Driver: p.Driver.BasicData.Name, Price: p.Price}
+		}
+		return out
+	}), nil
+}
@@ -0,0 +1,51 @@
+package pricingservice
+
+import "testing"
+
+func TestRunSensitivityPerturbsAndRestores(t *testing.T) {
+	cfg := struct{ A, B, Zero float64 }{A: 2, B: 1}
+	params := sensitivityParams(sensitivityGroup{name: "Cfg", ptr: &cfg})
+	if len(params) != 3 {
+		t.Fatalf("%d params, want 3", len(params))
+	}
+
+	// x = A·1, y = B·10: B moves y ten times as far as A moves x
+	report := runSensitivity("test", params, SensitivityConfig{Step: 0.5, Steps: map[string]float64{"Cfg.A": 0.25}},
+		func() []SensitivityPrice {
+			return []SensitivityPrice{{"x", cfg.A}, {"y", 10 * cfg.B}}
+		})
+
+	if cfg.A != 2 || cfg.B != 1 {
+		t.Errorf("parameters not restored: %+v", cfg)
+	}
+	if len(report.Results) != 2 {
+		t.Fatalf("%d results, want 2 (zero parameter skipped)", len(report.Results))
+	}
+	b, a := report.Results[0], report.Results[1]
+	if b.Parameter != "Cfg.B" || a.Parameter != "Cfg.A" {
+		t.Fatalf("order = %s, %s; want largest swing first", b.Parameter, a.Parameter)
+	}
+	if !approx(a.Low, 1.5) || !approx(a.High, 2.5) {
+		t.Errorf("Cfg.A perturbed to %v/%v, want per-parameter step ±25%%", a.Low, a.High)
+	}
+	if !approx(b.Swing(), 5) {
+		t.Errorf("Cfg.B swing = %v, want 5", b.Swing())
+	}
+	if !approx(b.HighSpend-b.BaseSpend, 5) {
+		t.Errorf("Cfg.B high spend change = %v, want 5", b.HighSpend-b.BaseSpend)
+	}
+	// low y = 5 still tops x = 2: no rank moves
+	if b.RankMoves() != 0 {
+		t.Errorf("Cfg.B rank moves = %d, want 0", b.RankMoves())
+	}
+}
+
+func TestPriceRanks(t *testing.T) {
+	got := priceRanks([]SensitivityPrice{{"a", 10}, {"b", 30}, {"c", 20}, {"d", 30}})
+	want := []int{4, 1, 3, 2}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("rank[%d] = %d, want %d", i, got[i], want[i])
+		}
+	}
+}
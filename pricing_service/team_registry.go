@@ -0,0 +1,177 @@
+package pricingservice
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+//
+// TEAM IDENTITY REGISTRY (shared by every model)
+//
+
+// Team names in the input are free text, and the same constructor turns up
+// under several of them: spellings ("Red Bull" / "Red Bull Racing") and
+// rebrands (Toro Rosso → AlphaTauri → RB → Racing Bulls). The registry
+// maps every known name to one canonical team ID, so a rebrand is not a
+// team change and two spellings are not two teams.
+//
+// Each identity has a rebrand history (the name in use from a season on)
+// and any other spellings. New teams are added explicitly, through a
+// registry file (LoadTeamRegistry); pricing never adds one, so a misspelt
+// team is reported instead of turning into a team of its own.
+
+// TeamName is the name a team raced under from season From on.
+type TeamName struct {
+	Name string
+	From int // first season (0 = since before the data)
+}
+
+// TeamIdentity is one constructor across its names.
+type TeamIdentity struct {
+	ID      string
+	Names   []TeamName // rebrand history, oldest first
+	Aliases []string   // other spellings, valid in any season
+}
+
+// TeamRegistry resolves team names to canonical IDs.
+type TeamRegistry struct {
+	Teams  []TeamIdentity
+	byName map[string]int // lower-case name or alias → index in Teams
+}
+
+// NewTeamRegistry returns the built-in registry of the F1 grid and the
+// names its teams raced under in the recent past.
+func NewTeamRegistry() *TeamRegistry {
+	r, err := newTeamRegistry([]TeamIdentity{
+		{ID: "mclaren", Names: []TeamName{{Name: "McLaren"}}, Aliases: []string{"McLaren F1 Team"}},
+		{ID: "ferrari", Names: []TeamName{{Name: "Ferrari"}}, Aliases: []string{"Scuderia Ferrari"}},
+		{ID: "mercedes", Names: []TeamName{{Name: "Mercedes"}}, Aliases: []string{"Mercedes-AMG", "Mercedes AMG Petronas"}},
+		{ID: "red-bull", Names: []TeamName{{Name: "Red Bull Racing"}}, Aliases: []string{"Red Bull", "Oracle Red Bull Racing"}},
+		{ID: "racing-bulls", Names: []TeamName{
+			{Name: "Toro Rosso"}, {Name: "AlphaTauri", From: 2020}, {Name: "RB", From: 2024}, {Name: "Racing Bulls", From: 2025},
+		}, Aliases: []string{"Scuderia Toro Rosso", "Scuderia AlphaTauri", "Visa Cash App RB", "VCARB"}},
+		{ID: "aston-martin", Names: []TeamName{
+			{Name: "Force India"}, {Name: "Racing Point", From: 2019}, {Name: "Aston Martin", From: 2021},
+		}, Aliases: []string{"Sahara Force India", "Aston Martin Aramco"}},
+		{ID: "alpine", Names: []TeamName{{Name: "Renault"}, {Name: "Alpine", From: 2021}}, Aliases: []string{"BWT Alpine"}},
+		{ID: "williams", Names: []TeamName{{Name: "Williams"}}, Aliases: []string{"Williams Racing"}},
+		{ID: "haas", Names: []TeamName{{Name: "Haas"}}, Aliases: []string{"Haas F1 Team", "MoneyGram Haas"}},
+		{ID: "sauber", Names: []TeamName{
+			{Name: "Sauber"}, {Name: "Alfa Romeo", From: 2019}, {Name: "Kick Sauber", From: 2024}, {Name: "Audi", From: 2026},
+		}, Aliases: []string{"Alfa Romeo Racing", "Stake F1 Team", "Audi F1 Team"}},
+		{ID: "cadillac", Names: []TeamName{{Name: "Cadillac", From: 2026}}},
+	})
+	if err != nil {
+		panic(err) // the built-in table is fixed; see TestNewTeamRegistry
+	}
+	return r
+}
+
+func newTeamRegistry(teams []TeamIdentity) (*TeamRegistry, error) {
+	r := &TeamRegistry{byName: map[string]int{}}
+	for _, t := range teams {
+		if err := r.add(t); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// add appends an identity, indexing its names and aliases.
+func (r *TeamRegistry) add(t TeamIdentity) error {
+	t.ID = strings.ToLower(strings.TrimSpace(t.ID))
+	if t.ID == "" || len(t.Names) == 0 {
+		return fmt.Errorf("team identity %q: ID and at least one name are required", t.ID)
+	}
+	sort.SliceStable(t.Names, func(i, j int) bool { return t.Names[i].From < t.Names[j].From })
+	keys := make([]string, 0, 1+len(t.Names)+len(t.Aliases))
+	keys = append(keys, t.ID)
+	for _, n := range t.Names {
+		keys = append(keys, n.Name)
+	}
+	keys = append(keys, t.Aliases...)
+
+	own := map[string]bool{}
+	for _, k := range keys {
+		k = strings.ToLower(strings.TrimSpace(k))
+		if k == "" {
+			return fmt.Errorf("team identity %q: empty name", t.ID)
+		}
+		if other, ok := r.byName[k]; ok {
+			return fmt.Errorf("team name %q belongs to both %q and %q", k, r.Teams[other].ID, t.ID)
+		}
+		own[k] = true
+	}
+	for k := range own {
+		r.byName[k] = len(r.Teams)
+	}
+	r.Teams = append(r.Teams, t)
+	return nil
+}
+
+// LoadTeamRegistry reads a registry JSON file ({"Teams": [TeamIdentity…]})
+// on top of the built-in one: an identity with a built-in ID replaces it,
+// any other is added.
+func LoadTeamRegistry(path string) (*TeamRegistry, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading team registry: %v", err)
+	}
+	var file struct{ Teams []TeamIdentity }
+	if err := json.Unmarshal(raw, &file); err != nil {
+		return nil, fmt.Errorf("error parsing team registry: %v", err)
+	}
+
+	replaced := map[string]TeamIdentity{}
+	for _, t := range file.Teams {
+		replaced[strings.ToLower(strings.TrimSpace(t.ID))] = t
+	}
+	var teams []TeamIdentity
+	for _, t := range NewTeamRegistry().Teams {
+		if _, ok := replaced[t.ID]; !ok {
+			teams = append(teams, t)
+		}
+	}
+	teams = append(teams, file.Teams...)
+	r, err := newTeamRegistry(teams)
+	if err != nil {
+		return nil, fmt.Errorf("team registry: %v", err)
+	}
+	return r, nil
+}
+
+// Resolve returns the ID of a team name, alias or ID, in any season.
+func (r *TeamRegistry) Resolve(name string) (string, error) {
+	if i, ok := r.byName[strings.ToLower(strings.TrimSpace(name))]; ok {
+		return r.Teams[i].ID, nil
+	}
+	return "", fmt.Errorf("unknown team %q", name)
+}
+
+// is reports whether name resolves to team id (false for a name the
+// registry does not know).
+func (r *TeamRegistry) is(name, id string) bool {
+	got, err := r.Resolve(name)
+	return err == nil && got == id
+}
+
+// NameIn is the name team id raced under in year (its newest name for
+// year 0, the ID itself for an unknown team).
+func (r *TeamRegistry) NameIn(id string, year int) string {
+	for _, t := range r.Teams {
+		if t.ID != id {
+			continue
+		}
+		name := t.Names[0].Name
+		for _, n := range t.Names {
+			if year == 0 || n.From <= year {
+				name = n.Name
+			}
+		}
+		return name
+	}
+	return id
+}
@@ -0,0 +1,74 @@
+package pricingservice
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestNewTeamRegistry(t *testing.T) {
+	r := NewTeamRegistry()
+	for _, name := range []string{"Red Bull", "red bull racing", "RED-BULL"} {
+		if id, err := r.Resolve(name); err != nil || id != "red-bull" {
+			t.Errorf("Resolve(%q) = %q, %v; want red-bull", name, id, err)
+		}
+	}
+	if _, err := r.Resolve("Brawn"); err == nil {
+		t.Errorf("unknown team resolved")
+	}
+	for year, want := range map[int]string{2019: "Toro Rosso", 2023: "AlphaTauri", 2024: "RB", 2025: "Racing Bulls", 0: "Racing Bulls"} {
+		if got := r.NameIn("racing-bulls", year); got != want {
+			t.Errorf("NameIn(racing-bulls, %d) = %q, want %q", year, got, want)
+		}
+	}
+	if !r.is(" Kick Sauber", "sauber") || r.is("Brawn GP", "brawn gp") {
+		t.Errorf("is() did not compare registry IDs")
+	}
+}
+
+// testTeamRegistry is the built-in registry plus one team per name.
+func testTeamRegistry(t *testing.T, names ...string) *TeamRegistry {
+	t.Helper()
+	teams := NewTeamRegistry().Teams
+	for _, n := range names {
+		teams = append(teams, TeamIdentity{ID: n, Names: []TeamName{{Name: n}}})
+	}
+	r, err := newTeamRegistry(teams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+func TestLoadTeamRegistry(t *testing.T) {
+	write := func(body string) string {
+		path := filepath.Join(t.TempDir(), "teams.json")
+		if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	r, err := LoadTeamRegistry(write(`{"Teams": [
+		{"ID": "haas", "Names": [{"Name": "Haas"}, {"Name": "TGR Haas", "From": 2026}]},
+		{"ID": "brawn", "Names": [{"Name": "Brawn GP"}]}
+	]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := r.NameIn("haas", 2026); got != "TGR Haas" {
+		t.Errorf("replaced identity name = %q", got)
+	}
+	if _, err := r.Resolve("Haas F1 Team"); err == nil {
+		t.Errorf("built-in alias survived the replacement")
+	}
+	if id, _ := r.Resolve("brawn gp"); id != "brawn" {
+		t.Errorf("added identity = %q", id)
+	}
+
+	if _, err := LoadTeamRegistry(write(`{"Teams": [{"ID": "rb2", "Names": [{"Name": "Red Bull"}]}]}`)); err == nil {
+		t.Errorf("accepted a name claimed by two teams")
+	}
+	if _, err := LoadTeamRegistry(write(`{"Teams": [{"ID": "x"}]}`)); err == nil {
+		t.Errorf("accepted an identity with no name")
+	}
+}